@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/engine"
+	"github.com/blueberrycongee/docuguard/internal/matcher"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/internal/ui"
+)
+
+var (
+	auditFormat      string
+	auditDocs        []string
+	auditCode        []string
+	auditTwoStage    bool
+	auditDryRun      bool
+	auditConcurrency int
+	auditBaseline    string
+	auditProgress    string
+	auditFresh       bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Check all exported code against the documentation",
+	Long: `Check every exported Go declaration against the documentation sections
+that mention it by name, whether or not a diff touched it. This finds drift
+that predates DocuGuard without writing bindings first.
+
+Finished checks are recorded in a progress file. If an audit is interrupted,
+running it again resumes where it stopped; the file is removed once an
+audit completes without errors.
+
+Examples:
+  docuguard audit                          # Audit the whole module
+  docuguard audit --dry-run                # Only count symbols and matches
+  docuguard audit --code 'internal/**/*.go' --format sarif > audit.sarif
+  docuguard audit --fresh                  # Ignore progress from an earlier run`,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditFormat, "format", "text", "output format (text|json|sarif)")
+	auditCmd.Flags().StringSliceVar(&auditDocs, "docs", []string{"README.md", "docs/**/*.md"}, "documentation patterns to scan")
+	auditCmd.Flags().StringSliceVar(&auditCode, "code", []string{"**/*.go"}, "Go source patterns to audit")
+	auditCmd.Flags().BoolVar(&auditTwoStage, "two-stage", false, "use broad keyword matching + LLM relevance filter instead of name matching")
+	auditCmd.Flags().BoolVar(&auditDryRun, "dry-run", false, "only count symbols and matching documentation, skip the LLM check")
+	auditCmd.Flags().IntVar(&auditConcurrency, "concurrency", 0, "maximum parallel LLM requests (default: llm.max_concurrency)")
+	auditCmd.Flags().StringVar(&auditBaseline, "baseline", "", "baseline file of known findings, empty to disable (default: rules.baseline)")
+	auditCmd.Flags().StringVar(&auditProgress, "progress", engine.DefaultAuditJournal, "file recording finished checks for resuming")
+	auditCmd.Flags().BoolVar(&auditFresh, "fresh", false, "discard progress from an earlier audit")
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	progress := progressWriter(auditFormat)
+	printer := ui.NewPrinter(progress, false)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if auditConcurrency > 0 {
+		cfg.LLM.MaxConcurrency = auditConcurrency
+	}
+	applyBaselineFlag(cmd, cfg, auditBaseline)
+
+	if auditDryRun {
+		return auditDryRunSummary(cfg, printer)
+	}
+	if !llmConfigured(cfg) {
+		return fmt.Errorf("audit needs a configured LLM; use --dry-run to list matches only")
+	}
+
+	prEngine, err := engine.NewPREngine(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create PR engine: %w", err)
+	}
+
+	journal, err := engine.OpenAuditJournal(auditProgress, auditFresh)
+	if err != nil {
+		return fmt.Errorf("failed to open progress file: %w", err)
+	}
+	if n := journal.Len(); n > 0 {
+		printer.Info("Resuming audit: %d check(s) recorded in %s", n, journal.Path())
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	opts := engine.AuditOptions{
+		CodePatterns: auditCode,
+		DocPatterns:  auditDocs,
+		UseTwoStage:  auditTwoStage,
+		Journal:      journal,
+		OnCheck: func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\rChecked %d/%d pairs", done, total)
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		},
+	}
+
+	report, err := prEngine.Audit(ctx, opts)
+	if err != nil {
+		journal.Close()
+		if ctx.Err() != nil {
+			fmt.Fprintln(os.Stderr)
+			return fmt.Errorf("audit interrupted, run it again to resume: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to audit: %w", err)
+	}
+
+	if err := journal.Err(); err != nil {
+		printer.Warning("Progress could not be recorded: %v", err)
+	}
+	if report.Errors > 0 || report.Skipped > 0 {
+		journal.Close()
+		printer.Warning("%d check(s) failed and %d skipped; run the audit again to retry only those", report.Errors, report.Skipped)
+	} else if err := journal.Remove(); err != nil {
+		printer.Warning("Failed to remove progress file: %v", err)
+	}
+
+	if auditFormat == "text" {
+		outputReportText(report, "Symbols audited")
+	} else if err := outputReport(report, auditFormat); err != nil {
+		return err
+	}
+	exitOnBlocking(cfg, report)
+	return nil
+}
+
+// auditDryRunSummary counts the symbols and matching documentation an
+// audit would check, without calling the LLM.
+func auditDryRunSummary(cfg *config.Config, printer *ui.Printer) error {
+	symbols, err := engine.AuditSymbols(auditCode, docScanOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to extract symbols: %w", err)
+	}
+	printer.Success("Found %d exported symbol(s)", len(symbols))
+
+	segments, err := scanner.ScanMarkdownDir(".", auditDocs, docScanOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to scan documents: %w", err)
+	}
+	printer.Success("Found %d document segments", len(segments))
+
+	pairs := matcher.NameMatch(symbols, segments)
+	printer.Success("Found %d pair(s) to check", len(pairs))
+	for _, p := range pairs {
+		printer.Println("  %s <-> %s (%s)", ui.Highlight(p.Segment.Heading), ui.Highlight(p.Symbol.Name), ui.Dim(p.Segment.File))
+	}
+	if n := len(matcher.NameSuppressed(symbols, segments)); n > 0 {
+		printer.Println("  %s", ui.Dim(fmt.Sprintf("%d pair(s) suppressed by docuguard:ignore", n)))
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/baseline"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/engine"
+	"github.com/blueberrycongee/docuguard/internal/glob"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var (
+	baselineFrom   []string
+	baselineOutput string
+)
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the baseline of known findings",
+	Long: `Record known inconsistencies so they do not fail later runs.
+
+check and pr subtract findings listed in the baseline file (rules.baseline,
+default .docuguard-baseline.json), report them separately, and list
+baseline entries that no longer reproduce.
+
+Examples:
+  docuguard baseline create
+  docuguard pr --format json > pr.json && docuguard baseline create --from pr.json`,
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Write a baseline of current findings",
+	Long: `Write a baseline of current findings.
+
+Without --from, all documents matched by scan.include are checked as with
+'docuguard check --all'. With --from, findings are read from JSON reports
+produced by 'check --format json' or 'pr --format json'.`,
+	RunE: runBaselineCreate,
+}
+
+func init() {
+	baselineCreateCmd.Flags().StringSliceVar(&baselineFrom, "from", nil, "read findings from JSON reports instead of running a check")
+	baselineCreateCmd.Flags().StringVarP(&baselineOutput, "output", "o", "", "baseline file to write (default: rules.baseline)")
+
+	baselineCmd.AddCommand(baselineCreateCmd)
+	rootCmd.AddCommand(baselineCmd)
+}
+
+// applyBaselineFlag overrides rules.baseline when --baseline was given.
+// An empty value disables the baseline.
+func applyBaselineFlag(cmd *cobra.Command, cfg *config.Config, path string) {
+	if cmd.Flags().Changed("baseline") {
+		cfg.Rules.Baseline = path
+	}
+}
+
+func runBaselineCreate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	output := baselineOutput
+	if output == "" {
+		output = cfg.Rules.Baseline
+	}
+	if output == "" {
+		output = baseline.DefaultFile
+	}
+
+	var b *baseline.Baseline
+	if len(baselineFrom) > 0 {
+		b, err = baselineFromReports(baselineFrom)
+	} else {
+		b, err = baselineFromCheck(cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := b.Save(output); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	fmt.Printf("Wrote %d finding(s) to %s\n", len(b.Entries), output)
+	return nil
+}
+
+// baselineFromCheck checks every configured document and records its inconsistencies.
+func baselineFromCheck(cfg *config.Config) (*baseline.Baseline, error) {
+	files, err := scanner.FindMarkdownFiles(".", cfg.Scan.Include, glob.Options{
+		Exclude:   cfg.Scan.Exclude,
+		GitIgnore: cfg.Scan.GitIgnore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand scan patterns: %w", err)
+	}
+
+	eng, err := engine.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize engine: %w", err)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	var reports []*types.Report
+	for _, file := range files {
+		report, err := eng.CheckFile(ctx, file)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("check interrupted: %w", ctx.Err())
+			}
+			fmt.Fprintf(os.Stderr, "failed to check %s: %v\n", file, err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return baseline.FromReport(mergeReports(reports)), nil
+}
+
+// baselineFromReports records the inconsistencies of saved JSON reports.
+// A file may hold several reports, as written by 'check --format json' for
+// multiple documents.
+func baselineFromReports(paths []string) (*baseline.Baseline, error) {
+	b := &baseline.Baseline{Entries: []types.BaselineEntry{}}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report: %w", err)
+		}
+
+		dec := json.NewDecoder(f)
+		for dec.More() {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+			}
+			entries, err := reportEntries(raw)
+			if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+			}
+			for _, e := range entries {
+				b.Add(baseline.Finding{DocFile: e.DocFile, Heading: e.Heading, Symbol: e.Symbol, Reason: e.Reason})
+			}
+		}
+		f.Close()
+	}
+	return b, nil
+}
+
+// reportEntries decodes a check or PR report and returns its findings.
+func reportEntries(data []byte) ([]types.BaselineEntry, error) {
+	// PR reports count changed symbols; check reports count bindings.
+	var probe struct {
+		TotalSymbols *int `json:"total_symbols"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	if probe.TotalSymbols != nil {
+		var report types.PRReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, err
+		}
+		return baseline.FromPRReport(&report).Entries, nil
+	}
+
+	var report types.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return baseline.FromReport(&report).Entries, nil
+}
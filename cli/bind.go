@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/engine"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/internal/ui"
+)
+
+var (
+	bindFormat        string
+	bindDocs          []string
+	bindCode          []string
+	bindMinConfidence float64
+	bindMax           int
+	bindConcurrency   int
+	bindFixOpts       fixOptions
+)
+
+var bindCmd = &cobra.Command{
+	Use:   "bind",
+	Short: "Manage doc-code binding annotations",
+	Long: `Manage the docuguard:start/bindCode/end annotations that 'docuguard check'
+verifies.
+
+Examples:
+  docuguard bind suggest
+  docuguard bind suggest --docs 'docs/api/**/*.md' --write`,
+}
+
+var bindSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Propose bindings for documentation sections",
+	Long: `Propose bindings for documentation sections that have none.
+
+Every exported symbol is matched against every section by broad keyword
+matching, and the LLM relevance filter confirms the candidates. Each
+proposal is listed with its confidence and reason. With --write, the body
+of each section below its heading is wrapped in a docuguard:start/end block
+holding the proposed bindCode directives.
+
+Examples:
+  docuguard bind suggest                        # List proposals
+  docuguard bind suggest --min-confidence 0.6 --max 2
+  docuguard bind suggest -i                     # Choose per section
+  docuguard bind suggest --write                # Insert all proposals`,
+	RunE: runBindSuggest,
+}
+
+func init() {
+	bindSuggestCmd.Flags().StringVar(&bindFormat, "format", "text", "output format (text|json)")
+	bindSuggestCmd.Flags().StringSliceVar(&bindDocs, "docs", []string{"README.md", "docs/**/*.md"}, "documentation patterns to scan")
+	bindSuggestCmd.Flags().StringSliceVar(&bindCode, "code", []string{"**/*.go"}, "Go source patterns to bind")
+	bindSuggestCmd.Flags().Float64Var(&bindMinConfidence, "min-confidence", 0.5, "minimum broad-match confidence of a proposal")
+	bindSuggestCmd.Flags().IntVar(&bindMax, "max", 3, "maximum proposals per section, 0 for no limit")
+	bindSuggestCmd.Flags().IntVar(&bindConcurrency, "concurrency", 0, "maximum parallel LLM requests (default: llm.max_concurrency)")
+	addFixFlags(bindSuggestCmd, &bindFixOpts)
+
+	bindCmd.AddCommand(bindSuggestCmd)
+	rootCmd.AddCommand(bindCmd)
+}
+
+func runBindSuggest(cmd *cobra.Command, args []string) error {
+	progress := progressWriter(bindFormat)
+	printer := ui.NewPrinter(progress, false)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if bindConcurrency > 0 {
+		cfg.LLM.MaxConcurrency = bindConcurrency
+	}
+	if !llmConfigured(cfg) {
+		return fmt.Errorf("bind suggest needs a configured LLM")
+	}
+
+	symbols, err := engine.AuditSymbols(bindCode, docScanOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to extract symbols: %w", err)
+	}
+	printer.Success("Found %d exported symbol(s)", len(symbols))
+
+	segments, err := scanner.ScanMarkdownDir(".", bindDocs, docScanOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to scan documents: %w", err)
+	}
+	printer.Success("Found %d document segments", len(segments))
+
+	prEngine, err := engine.NewPREngine(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create PR engine: %w", err)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	sections, failed, err := prEngine.SuggestBindings(ctx, symbols, segments, engine.SuggestOptions{
+		MinConfidence: bindMinConfidence,
+		MaxPerSection: bindMax,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("suggest interrupted: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to suggest bindings: %w", err)
+	}
+	for _, err := range failed {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	if bindFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(sections); err != nil {
+			return err
+		}
+	} else {
+		outputSuggestionsText(sections)
+	}
+
+	if !bindFixOpts.apply() && !bindFixOpts.Interactive {
+		if len(sections) > 0 {
+			printer.Info("Use --write to insert these bindings, or -i to choose per section")
+		}
+		return nil
+	}
+
+	edits, failed := engine.BindEdits(sections)
+	return handleFixes(&engine.FixResult{Edits: edits, Failed: failed}, bindFixOpts, os.Stdin, os.Stdout, os.Stderr)
+}
+
+func outputSuggestionsText(sections []engine.SectionSuggestion) {
+	if len(sections) == 0 {
+		fmt.Println("No bindings to suggest")
+		return
+	}
+
+	fmt.Println()
+	for _, s := range sections {
+		fmt.Printf("%s:%d %s\n", s.Segment.File, s.Segment.StartLine, ui.Highlight(s.Segment.Heading))
+		for _, b := range s.Suggestions {
+			fmt.Printf("  + %s %s (%s) %.2f %s\n", b.Symbol.Type, ui.Highlight(b.Symbol.Name), ui.Dim(b.Symbol.File),
+				b.Confidence, ui.Dim(b.Reason))
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/cache"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/ui"
+)
+
+var cacheMaxAge time.Duration
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the LLM response cache",
+	Long: `Inspect and trim the on-disk cache of LLM verdicts.
+
+Verdicts are keyed by provider, model, prompt version and the analyzed
+doc/code content, so unchanged bindings are not re-sent to the LLM.
+
+Examples:
+  docuguard cache stats
+  docuguard cache prune --max-age 168h
+  docuguard cache clear`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache size and entry count",
+	RunE:  runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cache entries",
+	RunE:  runCacheClear,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove entries not used recently",
+	RunE:  runCachePrune,
+}
+
+func init() {
+	cachePruneCmd.Flags().DurationVar(&cacheMaxAge, "max-age", 0, "remove entries unused for longer than this (default: cache.max_age)")
+
+	cacheCmd.AddCommand(cacheStatsCmd, cacheClearCmd, cachePruneCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+func loadCacheStore() (*cache.Store, *config.Config, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cache.NewStore(cfg.Cache.Dir), cfg, nil
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	store, cfg, err := loadCacheStore()
+	if err != nil {
+		return err
+	}
+
+	st, err := store.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	fmt.Printf("Cache: %s\n", ui.Highlight(store.Dir()))
+	if !cfg.Cache.Enabled {
+		fmt.Printf("  Status:  %s\n", ui.Warning("disabled"))
+	}
+	fmt.Printf("  Entries: %d\n", st.Entries)
+	fmt.Printf("  Size:    %s\n", formatBytes(st.SizeBytes))
+	if st.Entries > 0 {
+		fmt.Printf("  Oldest:  %s\n", ui.Dim(st.Oldest.Format(time.RFC3339)))
+		fmt.Printf("  Newest:  %s\n", ui.Dim(st.Newest.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	store, _, err := loadCacheStore()
+	if err != nil {
+		return err
+	}
+
+	removed, err := store.Clear()
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	ui.NewPrinter(os.Stdout, false).Success("Removed %d cache entries", removed)
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	store, cfg, err := loadCacheStore()
+	if err != nil {
+		return err
+	}
+
+	maxAge := cacheMaxAge
+	if maxAge == 0 {
+		maxAge = cfg.Cache.MaxAge
+	}
+	if maxAge <= 0 {
+		return fmt.Errorf("--max-age must be positive; use 'docuguard cache clear' to remove everything")
+	}
+
+	removed, err := store.Prune(maxAge)
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+
+	ui.NewPrinter(os.Stdout, false).Success("Removed %d cache entries unused for %s", removed, maxAge)
+	return nil
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,22 +1,24 @@
 package cli
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/blueberrycongee/docuguard/internal/config"
 	"github.com/blueberrycongee/docuguard/internal/engine"
+	"github.com/blueberrycongee/docuguard/internal/glob"
 	"github.com/blueberrycongee/docuguard/internal/reporter"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
 var (
-	checkAll     bool
-	outputFormat string
+	checkAll         bool
+	outputFormat     string
+	checkConcurrency int
+	checkBaseline    string
 )
 
 var checkCmd = &cobra.Command{
@@ -34,12 +36,14 @@ Examples:
 
 func init() {
 	checkCmd.Flags().BoolVar(&checkAll, "all", false, "check all configured documents")
-	checkCmd.Flags().StringVar(&outputFormat, "format", "text", "output format (text|json|github-actions)")
+	checkCmd.Flags().StringVar(&outputFormat, "format", "text", "output format (text|json|github-actions|sarif)")
+	checkCmd.Flags().StringVar(&checkBaseline, "baseline", "", "baseline file of known findings, empty to disable (default: rules.baseline)")
+	checkCmd.Flags().IntVar(&checkConcurrency, "concurrency", 0, "maximum parallel LLM requests (default: llm.max_concurrency)")
 	rootCmd.AddCommand(checkCmd)
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(cfgFile)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -47,84 +51,115 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if outputFormat != "" {
 		cfg.Output.Format = outputFormat
 	}
+	if checkConcurrency > 0 {
+		cfg.LLM.MaxConcurrency = checkConcurrency
+	}
+	applyBaselineFlag(cmd, cfg, checkBaseline)
 
-	var files []string
-	if checkAll {
-		// Expand glob patterns to actual file paths
-		files = expandGlobPatterns(".", cfg.Scan.Include)
-		if len(files) == 0 {
-			return fmt.Errorf("no files found matching patterns: %v", cfg.Scan.Include)
-		}
-	} else if len(args) > 0 {
-		files = args
-	} else {
-		return fmt.Errorf("please specify files to check or use --all")
+	files, err := docFiles(cfg, args, checkAll)
+	if err != nil {
+		return err
 	}
 
 	eng, err := engine.New(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize engine: %w", err)
 	}
+	warnUnresolved(eng.Unresolved())
 
 	rep := reporter.New(cfg.Output.Format, cfg.Output.Color)
+	if sarif, ok := rep.(*reporter.SARIFReporter); ok {
+		sarif.ToolVersion = Version
+	}
+	// A SARIF log is a single document, so per-file reports are merged and written once.
+	merge := cfg.Output.Format == "sarif"
+	var reports []*types.Report
 
-	ctx := context.Background()
-	hasInconsistent := false
+	ctx, cancel := signalContext()
+	defer cancel()
+	hasBlocking := false
 
 	for _, file := range files {
 		report, err := eng.CheckFile(ctx, file)
 		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("check interrupted: %w", ctx.Err())
+			}
 			fmt.Fprintf(os.Stderr, "failed to check %s: %v\n", file, err)
 			continue
 		}
 
-		_ = rep.Report(os.Stdout, report)
+		if merge {
+			reports = append(reports, report)
+		} else {
+			_ = rep.Report(os.Stdout, report)
+		}
 
-		if report.Inconsistent > 0 {
-			hasInconsistent = true
+		// Only findings at or above rules.severity_threshold fail the run.
+		if report.Blocking > 0 {
+			hasBlocking = true
 		}
 	}
 
-	if hasInconsistent && cfg.Rules.FailOnInconsistent {
+	if merge {
+		_ = rep.Report(os.Stdout, mergeReports(reports))
+	}
+
+	if hasBlocking && cfg.Rules.FailOnInconsistent {
 		os.Exit(1)
 	}
 
 	return nil
 }
 
-// expandGlobPatterns expands glob patterns to actual file paths.
-func expandGlobPatterns(rootDir string, patterns []string) []string {
-	seen := make(map[string]bool)
-	var files []string
-
-	for _, pattern := range patterns {
-		fullPattern := filepath.Join(rootDir, pattern)
-		matches, _ := filepath.Glob(fullPattern)
-
-		for _, match := range matches {
-			info, err := os.Stat(match)
-			if err != nil || info.IsDir() {
-				continue
-			}
-			if strings.HasSuffix(strings.ToLower(match), ".md") {
-				if !seen[match] {
-					seen[match] = true
-					files = append(files, match)
-				}
-			}
+// docFiles returns the documents named on the command line, or with all
+// set, every document matched by scan.include.
+func docFiles(cfg *config.Config, args []string, all bool) ([]string, error) {
+	if !all {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("please specify files to check or use --all")
 		}
+		return args, nil
+	}
 
-		// Handle non-glob patterns (direct file paths)
-		if !strings.Contains(pattern, "*") {
-			filePath := filepath.Join(rootDir, pattern)
-			if info, err := os.Stat(filePath); err == nil && !info.IsDir() {
-				if !seen[filePath] {
-					seen[filePath] = true
-					files = append(files, filePath)
-				}
-			}
-		}
+	files, err := scanner.FindMarkdownFiles(".", cfg.Scan.Include, glob.Options{
+		Exclude:   cfg.Scan.Exclude,
+		GitIgnore: cfg.Scan.GitIgnore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand scan patterns: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found matching patterns: %v", cfg.Scan.Include)
 	}
+	return files, nil
+}
 
-	return files
+// warnUnresolved reports //docuguard:doc directives that point at no
+// documentation section.
+func warnUnresolved(errs []error) {
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
+
+// mergeReports combines per-file reports into one.
+func mergeReports(reports []*types.Report) *types.Report {
+	merged := &types.Report{Results: []types.CheckResult{}}
+	for _, r := range reports {
+		merged.TotalBindings += r.TotalBindings
+		merged.Consistent += r.Consistent
+		merged.Inconsistent += r.Inconsistent
+		merged.Errors += r.Errors
+		merged.Skipped += r.Skipped
+		merged.Failures = append(merged.Failures, r.Failures...)
+		merged.Usage = addUsage(merged.Usage, r.Usage)
+		merged.Blocking += r.Blocking
+		merged.Baselined += r.Baselined
+		merged.StaleBaseline = append(merged.StaleBaseline, r.StaleBaseline...)
+		merged.Suppressed = append(merged.Suppressed, r.Suppressed...)
+		merged.ExecutionTimeMs += r.ExecutionTimeMs
+		merged.Results = append(merged.Results, r.Results...)
+	}
+	return merged
 }
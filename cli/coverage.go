@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/coverage"
+	"github.com/blueberrycongee/docuguard/internal/engine"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/internal/ui"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var (
+	coverageFormat string
+	coverageDocs   []string
+	coverageCode   []string
+	coverageGoDoc  bool
+	coverageMin    float64
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Report exported symbols the documentation does not describe",
+	Long: `List the exported functions, types, constants and variables that no
+documentation section mentions by name, grouped by package, with the
+percentage of documented symbols. No LLM is needed.
+
+Declarations marked with //docuguard:ignore are not counted.
+
+Examples:
+  docuguard coverage                     # README.md and docs/**/*.md
+  docuguard coverage --godoc             # Doc comments count as documentation
+  docuguard coverage --min 80            # Fail below 80% (for CI)
+  docuguard coverage --format json`,
+	RunE: runCoverage,
+}
+
+func init() {
+	coverageCmd.Flags().StringVar(&coverageFormat, "format", "text", "output format (text|json)")
+	coverageCmd.Flags().StringSliceVar(&coverageDocs, "docs", []string{"README.md", "docs/**/*.md"}, "documentation patterns to scan")
+	coverageCmd.Flags().StringSliceVar(&coverageCode, "code", []string{"**/*.go"}, "Go source patterns to measure")
+	coverageCmd.Flags().BoolVar(&coverageGoDoc, "godoc", false, "count symbols with a Go doc comment as documented")
+	coverageCmd.Flags().Float64Var(&coverageMin, "min", 0, "fail when coverage is below this percentage")
+	rootCmd.AddCommand(coverageCmd)
+}
+
+func runCoverage(cmd *cobra.Command, args []string) error {
+	cfg := loadPRConfig(progressWriter(coverageFormat))
+
+	symbols, err := engine.AuditSymbols(coverageCode, docScanOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to extract symbols: %w", err)
+	}
+
+	segments, err := scanner.ScanMarkdownDir(".", coverageDocs, docScanOptions(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to scan documents: %w", err)
+	}
+
+	var godoc []types.DocSegment
+	if coverageGoDoc {
+		if godoc, err = scanGoDoc(symbols); err != nil {
+			return fmt.Errorf("failed to scan doc comments: %w", err)
+		}
+	}
+
+	report := coverage.Compute(symbols, segments, godoc)
+	if coverageFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		outputCoverageText(report)
+	}
+
+	if report.Coverage < coverageMin {
+		fmt.Fprintf(os.Stderr, "documentation coverage %.1f%% is below the minimum of %.1f%%\n", report.Coverage, coverageMin)
+		os.Exit(1)
+	}
+	return nil
+}
+
+// scanGoDoc returns the doc comments of the packages declaring the symbols.
+func scanGoDoc(symbols []types.ChangedSymbol) ([]types.DocSegment, error) {
+	var segments []types.DocSegment
+	seen := make(map[string]bool)
+	for _, sym := range symbols {
+		dir := path.Dir(sym.File)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+
+		dirSegments, err := scanner.ScanGoDocDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, dirSegments...)
+	}
+	return segments, nil
+}
+
+func outputCoverageText(report *coverage.Report) {
+	printer := ui.NewPrinter(os.Stdout, false)
+
+	for _, pkg := range report.Packages {
+		fmt.Printf("%-40s %4d/%-4d %s\n", pkg.Path, pkg.Documented, pkg.Total, coverageColor(pkg.Coverage)(fmt.Sprintf("%5.1f%%", pkg.Coverage)))
+		for _, s := range pkg.Undocumented {
+			fmt.Printf("  - %s %s %s\n", s.Type, ui.Highlight(s.Name), ui.Dim(fmt.Sprintf("(%s:%d)", s.File, s.Line)))
+		}
+	}
+
+	fmt.Println()
+	printer.Info("Summary:")
+	fmt.Printf("  Exported symbols: %s\n", ui.Highlight(fmt.Sprintf("%d", report.Total)))
+	fmt.Printf("  Documented: %s\n", ui.Highlight(fmt.Sprintf("%d", report.Documented)))
+	if report.Ignored > 0 {
+		fmt.Printf("  Ignored: %s\n", ui.Dim(fmt.Sprintf("%d", report.Ignored)))
+	}
+	fmt.Printf("  Coverage: %s\n", coverageColor(report.Coverage)(fmt.Sprintf("%.1f%%", report.Coverage)))
+}
+
+// coverageColor highlights coverage below the --min threshold.
+func coverageColor(pct float64) func(a ...interface{}) string {
+	if pct < coverageMin {
+		return ui.Error
+	}
+	return ui.Success
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/engine"
+	"github.com/blueberrycongee/docuguard/internal/fix"
+	"github.com/blueberrycongee/docuguard/internal/git"
+	"github.com/blueberrycongee/docuguard/internal/reporter"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var (
+	fixAll         bool
+	fixConcurrency int
+	fixBaseline    string
+	fixOpts        fixOptions
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix [files...]",
+	Short: "Propose documentation edits for inconsistencies",
+	Long: `Check documents like 'docuguard check' and ask the LLM to rewrite each
+inconsistent binding block. Rewrites that would touch lines outside the block
+are rejected.
+
+By default the edits are printed as a unified diff. Use --write to apply them,
+--stage to apply and 'git add' them, and -i to accept or reject each edit.
+
+Examples:
+  docuguard fix docs/api.md > docs.patch && git apply docs.patch
+  docuguard fix --all --write
+  docuguard fix --all -i --stage`,
+	RunE: runFix,
+}
+
+// fixOptions selects what happens to proposed edits.
+type fixOptions struct {
+	Write       bool
+	Stage       bool
+	Interactive bool
+}
+
+// apply reports whether edits are written to disk rather than printed.
+func (o fixOptions) apply() bool {
+	return o.Write || o.Stage
+}
+
+func addFixFlags(cmd *cobra.Command, opts *fixOptions) {
+	cmd.Flags().BoolVar(&opts.Write, "write", false, "apply edits to the documentation files instead of printing a diff")
+	cmd.Flags().BoolVar(&opts.Stage, "stage", false, "apply edits and stage the changed files with git add")
+	cmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "ask before accepting each edit")
+}
+
+func init() {
+	fixCmd.Flags().BoolVar(&fixAll, "all", false, "fix all configured documents")
+	fixCmd.Flags().StringVar(&fixBaseline, "baseline", "", "baseline file of known findings, empty to disable (default: rules.baseline)")
+	fixCmd.Flags().IntVar(&fixConcurrency, "concurrency", 0, "maximum parallel LLM requests (default: llm.max_concurrency)")
+	addFixFlags(fixCmd, &fixOpts)
+	rootCmd.AddCommand(fixCmd)
+}
+
+func runFix(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if fixConcurrency > 0 {
+		cfg.LLM.MaxConcurrency = fixConcurrency
+	}
+	applyBaselineFlag(cmd, cfg, fixBaseline)
+
+	files, err := docFiles(cfg, args, fixAll)
+	if err != nil {
+		return err
+	}
+
+	eng, err := engine.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize engine: %w", err)
+	}
+	warnUnresolved(eng.Unresolved())
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	combined := &engine.FixResult{}
+	skipped, failed := 0, 0
+	for _, file := range files {
+		report, err := eng.CheckFile(ctx, file)
+		if err == nil {
+			skipped += report.Skipped
+			failed += len(report.Failures)
+			for _, f := range report.Failures {
+				fmt.Fprintf(os.Stderr, "failed to check %s\n", f)
+			}
+			combined.Usage = addUsage(combined.Usage, report.Usage)
+
+			var result *engine.FixResult
+			if result, err = eng.ProposeFixes(ctx, report); err == nil {
+				combined.Edits = append(combined.Edits, result.Edits...)
+				combined.Failed = append(combined.Failed, result.Failed...)
+				combined.Usage = addUsage(combined.Usage, result.Usage)
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("fix interrupted: %w", ctx.Err())
+			}
+			fmt.Fprintf(os.Stderr, "failed to fix %s: %v\n", file, err)
+			failed++
+		}
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped: %d binding(s) not checked, LLM budget exceeded\n", skipped)
+	}
+
+	if err := handleFixes(combined, fixOpts, os.Stdin, os.Stdout, os.Stderr); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed; their documentation was not fixed", failed)
+	}
+	return nil
+}
+
+// addUsage returns total with u added to it; nil stands for no usage.
+func addUsage(total, u *types.Usage) *types.Usage {
+	if u == nil {
+		return total
+	}
+	if total == nil {
+		total = &types.Usage{}
+	}
+	total.Add(*u)
+	return total
+}
+
+// handleFixes prints or applies proposed edits. Diffs go to out; prompts,
+// failures and progress go to msg.
+func handleFixes(result *engine.FixResult, opts fixOptions, in io.Reader, out, msg io.Writer) error {
+	for _, err := range result.Failed {
+		fmt.Fprintf(msg, "skipped %v\n", err)
+	}
+	if result.Usage != nil {
+		fmt.Fprintf(msg, "LLM usage: %s\n", reporter.FormatUsage(*result.Usage))
+	}
+	if len(result.Edits) == 0 {
+		fmt.Fprintln(msg, "No edits proposed")
+		return nil
+	}
+
+	edits := result.Edits
+	if opts.Interactive {
+		var err error
+		if edits, err = promptEdits(edits, in, msg); err != nil {
+			return err
+		}
+		if len(edits) == 0 {
+			fmt.Fprintln(msg, "No edits accepted")
+			return nil
+		}
+	}
+
+	if !opts.apply() {
+		patch, err := fix.UnifiedDiff(edits)
+		if err != nil {
+			return fmt.Errorf("failed to render diff: %w", err)
+		}
+		_, err = io.WriteString(out, patch)
+		return err
+	}
+
+	if err := fix.Apply(edits); err != nil {
+		return fmt.Errorf("failed to apply edits: %w", err)
+	}
+	files := editedFiles(edits)
+	if opts.Stage {
+		if err := git.Add(files...); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(msg, "Applied %d edit(s) to %s\n", len(edits), strings.Join(files, ", "))
+	return nil
+}
+
+// promptEdits shows each edit as a diff and asks whether to accept it.
+func promptEdits(edits []*fix.Edit, in io.Reader, msg io.Writer) ([]*fix.Edit, error) {
+	reader := bufio.NewReader(in)
+	var accepted []*fix.Edit
+	for i, e := range edits {
+		patch, err := fix.UnifiedDiff([]*fix.Edit{e})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render diff: %w", err)
+		}
+		fmt.Fprintf(msg, "\n[%d/%d] %s:%d (%s): %s\n%s", i+1, len(edits), e.File, e.StartLine, e.Symbol, e.Reason, patch)
+
+		switch askEdit(reader, msg) {
+		case "y":
+			accepted = append(accepted, e)
+		case "a":
+			return append(accepted, edits[i:]...), nil
+		case "q":
+			return accepted, nil
+		}
+	}
+	return accepted, nil
+}
+
+// askEdit reads an answer until it is one of y, n, a or q. End of input
+// answers q, rejecting the remaining edits.
+func askEdit(reader *bufio.Reader, msg io.Writer) string {
+	for {
+		fmt.Fprint(msg, "Accept this edit [y,n,a,q,?]? ")
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return "q"
+		}
+		switch answer = strings.ToLower(strings.TrimSpace(answer)); answer {
+		case "y", "n", "a", "q":
+			return answer
+		}
+		fmt.Fprintln(msg, "y - accept this edit\nn - reject this edit\na - accept this and all remaining edits\nq - reject this and all remaining edits")
+	}
+}
+
+// editedFiles lists the files touched by the edits in order of first appearance.
+func editedFiles(edits []*fix.Edit) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, e := range edits {
+		if !seen[e.File] {
+			seen[e.File] = true
+			files = append(files, e.File)
+		}
+	}
+	return files
+}
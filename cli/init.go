@@ -34,10 +34,13 @@ scan:
     - "README.md"
   exclude:
     - "docs/archive/**"
+  gitignore: true
 
 rules:
   fail_on_inconsistent: true
   confidence_threshold: 0.8
+  severity_threshold: "warning"
+  baseline: ".docuguard-baseline.json"
 
 output:
   format: "text"
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/lint"
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/internal/ui"
+)
+
+var (
+	lintAll    bool
+	lintFormat string
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [files...]",
+	Short: "Validate binding annotations without calling an LLM",
+	Long: `Check docuguard annotations for mistakes that 'docuguard check' would skip
+or report as inconsistencies: unclosed or nested blocks, bindCode outside a
+block, unknown binding kinds, code paths that do not exist, symbols the code
+file does not declare, and blocks without documentation.
+
+Diagnostics are printed as file:line: message (rule). The command exits
+with status 1 when any are found.
+
+Examples:
+  docuguard lint docs/api.md
+  docuguard lint --all
+  docuguard lint --all --format json`,
+	RunE: runLint,
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&lintAll, "all", false, "lint all configured documents")
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "output format (text|json)")
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	cfg := loadPRConfig(os.Stderr)
+
+	files, err := docFiles(cfg, args, lintAll)
+	if err != nil {
+		return err
+	}
+
+	goParser := parser.NewGoParser()
+	diags := []lint.Diagnostic{}
+	for _, file := range files {
+		fileDiags, err := lint.File(file, goParser)
+		if err != nil {
+			return fmt.Errorf("failed to lint %s: %w", file, err)
+		}
+		diags = append(diags, fileDiags...)
+	}
+
+	if lintFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{
+			"diagnostics": diags,
+			"count":       len(diags),
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, d := range diags {
+			fmt.Println(d)
+		}
+		printer := ui.NewPrinter(os.Stderr, false)
+		if len(diags) == 0 {
+			printer.Success("No problems found in %d file(s)", len(files))
+		} else {
+			printer.Error("%d problem(s) found in %d file(s)", len(diags), len(files))
+		}
+	}
+
+	if len(diags) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
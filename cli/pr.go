@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -12,6 +13,7 @@ import (
 	"github.com/blueberrycongee/docuguard/internal/engine"
 	"github.com/blueberrycongee/docuguard/internal/git"
 	"github.com/blueberrycongee/docuguard/internal/github"
+	"github.com/blueberrycongee/docuguard/internal/glob"
 	"github.com/blueberrycongee/docuguard/internal/matcher"
 	"github.com/blueberrycongee/docuguard/internal/reporter"
 	"github.com/blueberrycongee/docuguard/internal/scanner"
@@ -20,17 +22,21 @@ import (
 )
 
 var (
-	prBaseBranch string
-	prDryRun     bool
-	prFormat     string
-	prDocs       []string
-	prSkipLLM    bool
-	prTwoStage   bool
-	prGitHub     bool
-	prNumber     int
-	prToken      string
-	prRepo       string
-	prComment    bool
+	prBaseBranch  string
+	prDryRun      bool
+	prFormat      string
+	prDocs        []string
+	prSkipLLM     bool
+	prTwoStage    bool
+	prGitHub      bool
+	prNumber      int
+	prToken       string
+	prRepo        string
+	prComment     bool
+	prConcurrency int
+	prBaseline    string
+	prFix         bool
+	prFixOpts     fixOptions
 )
 
 var prCmd = &cobra.Command{
@@ -43,6 +49,7 @@ Local mode (development):
   docuguard pr --base main        # Specify base branch
   docuguard pr --base HEAD~3      # Compare last 3 commits
   docuguard pr --dry-run          # Only show detected changes
+  docuguard pr --fix --write      # Rewrite outdated doc sections
 
 GitHub mode (CI):
   docuguard pr --github --pr 123  # Check specific PR
@@ -53,10 +60,14 @@ GitHub mode (CI):
 func init() {
 	prCmd.Flags().StringVar(&prBaseBranch, "base", "main", "base branch for comparison")
 	prCmd.Flags().BoolVar(&prDryRun, "dry-run", false, "only show detected changes, skip consistency check")
-	prCmd.Flags().StringVar(&prFormat, "format", "text", "output format (text|json)")
+	prCmd.Flags().StringVar(&prFormat, "format", "text", "output format (text|json|sarif)")
 	prCmd.Flags().StringSliceVar(&prDocs, "docs", []string{"README.md", "docs/**/*.md"}, "documentation patterns to scan")
 	prCmd.Flags().BoolVar(&prSkipLLM, "skip-llm", false, "skip LLM check, use keyword matching only")
 	prCmd.Flags().BoolVar(&prTwoStage, "two-stage", false, "use two-stage matching (broad match + LLM relevance filter)")
+	prCmd.Flags().StringVar(&prBaseline, "baseline", "", "baseline file of known findings, empty to disable (default: rules.baseline)")
+	prCmd.Flags().IntVar(&prConcurrency, "concurrency", 0, "maximum parallel LLM requests (default: llm.max_concurrency)")
+	prCmd.Flags().BoolVar(&prFix, "fix", false, "propose rewrites of inconsistent doc sections (prints a diff unless --write or --stage)")
+	addFixFlags(prCmd, &prFixOpts)
 
 	prCmd.Flags().BoolVar(&prGitHub, "github", false, "enable GitHub mode")
 	prCmd.Flags().IntVar(&prNumber, "pr", 0, "PR number (required in GitHub mode)")
@@ -72,25 +83,38 @@ func runPR(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a git repository")
 	}
 
+	if prFix {
+		if prSkipLLM || prDryRun {
+			return fmt.Errorf("--fix needs an LLM check; remove --skip-llm and --dry-run")
+		}
+		if !prFixOpts.apply() && prFormat != "text" {
+			return fmt.Errorf("--fix prints a diff after the report; use --write or --stage with --format %s", prFormat)
+		}
+	}
+
 	if prGitHub {
-		return runPRGitHub()
+		return runPRGitHub(cmd)
 	}
-	return runPRLocal()
+	return runPRLocal(cmd)
 }
 
-func runPRLocal() error {
-	printer := ui.NewPrinter(os.Stdout, false)
+func runPRLocal(cmd *cobra.Command) error {
+	progress := progressWriter(prFormat)
+	printer := ui.NewPrinter(progress, false)
 
 	printer.Info("Analyzing changes from %s...", prBaseBranch)
-	fmt.Println()
+	fmt.Fprintln(progress)
 
 	diff, err := git.GetDiffUncommitted()
 	if err != nil {
 		return fmt.Errorf("failed to get uncommitted diff: %w", err)
 	}
 
+	// Uncommitted changes are diffed against HEAD, committed ones against the base branch.
+	baseRef := prBaseBranch
 	if diff != "" {
 		printer.Info("Checking uncommitted changes...")
+		baseRef = "HEAD"
 	} else {
 		diff, err = git.GetDiff(prBaseBranch)
 		if err != nil {
@@ -103,7 +127,7 @@ func runPRLocal() error {
 		return nil
 	}
 
-	extractor := git.NewSymbolExtractor()
+	extractor := git.NewSymbolExtractor(git.NewGitSources(baseRef))
 	symbols, err := extractor.ExtractChangedSymbols(diff)
 	if err != nil {
 		return fmt.Errorf("failed to extract symbols: %w", err)
@@ -119,13 +143,16 @@ func runPRLocal() error {
 			return outputSymbolsJSON(symbols)
 		}
 		outputSymbolsText(symbols, printer)
-		fmt.Println()
+		fmt.Fprintln(progress)
 		printer.Info("Use without --dry-run to check documentation consistency")
 		return nil
 	}
 
+	cfg := loadPRConfig(progress)
+	applyBaselineFlag(cmd, cfg, prBaseline)
+
 	printer.Info("Scanning documentation...")
-	segments, err := scanner.ScanMarkdownDir(".", prDocs)
+	segments, err := scanner.ScanMarkdownDir(".", prDocs, docScanOptions(cfg))
 	if err != nil {
 		return fmt.Errorf("failed to scan documents: %w", err)
 	}
@@ -135,36 +162,47 @@ func runPRLocal() error {
 		return nil
 	}
 	printer.Success("Found %d document segments", len(segments))
-	fmt.Println()
+	fmt.Fprintln(progress)
 
 	printer.Info("Finding relevant documentation...")
-	relevantPairs := matcher.QuickMatch(symbols, segments)
+	relevantPairs := declaredMatches(symbols, segments)
 	printer.Success("Found %d potential matches", len(relevantPairs))
-	fmt.Println()
+	fmt.Fprintln(progress)
 
-	if len(relevantPairs) == 0 {
+	// Pairs skipped by docuguard:ignore are still listed in the report.
+	if len(relevantPairs) == 0 && len(matcher.Suppressed(symbols, segments)) == 0 {
 		printer.Success("No documentation appears to be affected by these changes")
 		return nil
 	}
 
 	if !prSkipLLM {
-		cfg, err := config.Load(cfgFile)
-		if err == nil && cfg.LLM.APIKey != "" {
-			return runPRWithLLM(cfg, diff)
+		if llmConfigured(cfg) {
+			return runPRWithLLM(cfg, diff, baseRef)
 		}
-		printer.Warning("No LLM configured, using keyword matching only")
+		printer.Warning("No LLM configured, checking changed literals only")
 	}
 
-	if prFormat == "json" {
-		return outputRelevanceJSON(relevantPairs)
+	report, err := engine.OfflineReport(cfg, symbols, segments, relevantPairs)
+	if err != nil {
+		return fmt.Errorf("failed to check: %w", err)
 	}
-	outputRelevanceText(relevantPairs, printer)
-
+	if err := outputReport(report, prFormat); err != nil {
+		return err
+	}
+	if prFix {
+		return fmt.Errorf("--fix needs a configured LLM")
+	}
+	exitOnBlocking(cfg, report)
 	return nil
 }
 
-func runPRWithLLM(cfg *config.Config, diff string) error {
-	ctx := context.Background()
+func runPRWithLLM(cfg *config.Config, diff, baseRef string) error {
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	if prConcurrency > 0 {
+		cfg.LLM.MaxConcurrency = prConcurrency
+	}
 
 	prEngine, err := engine.NewPREngine(cfg)
 	if err != nil {
@@ -172,7 +210,7 @@ func runPRWithLLM(cfg *config.Config, diff string) error {
 	}
 
 	opts := engine.PRCheckOptions{
-		BaseBranch:  prBaseBranch,
+		BaseBranch:  baseRef,
 		DocPatterns: prDocs,
 		SkipLLM:     prSkipLLM,
 		UseTwoStage: prTwoStage,
@@ -183,15 +221,47 @@ func runPRWithLLM(cfg *config.Config, diff string) error {
 		return fmt.Errorf("failed to check: %w", err)
 	}
 
-	if prFormat == "json" {
-		return outputReportJSON(report)
+	if err := outputReport(report, prFormat); err != nil {
+		return err
 	}
-	outputReportText(report)
-
+	if err := fixPR(ctx, prEngine, report); err != nil {
+		return err
+	}
+	exitOnBlocking(cfg, report)
 	return nil
 }
 
-func runPRGitHub() error {
+// declaredMatches returns the sections that mention the changed symbols,
+// plus those their //docuguard:doc directives declare.
+func declaredMatches(symbols []types.ChangedSymbol, segments []types.DocSegment) []types.RelevanceResult {
+	declared, unresolved := engine.DeclaredPairs(symbols)
+	warnUnresolved(unresolved)
+	return engine.MergePairs(matcher.QuickMatch(symbols, segments), declared)
+}
+
+// exitOnBlocking ends the run with status 1, as check does, when the report
+// has findings at or above rules.severity_threshold and
+// rules.fail_on_inconsistent is set.
+func exitOnBlocking(cfg *config.Config, report *types.PRReport) {
+	if report.Blocking > 0 && cfg.Rules.FailOnInconsistent {
+		os.Exit(1)
+	}
+}
+
+// fixPR proposes rewrites for the report's inconsistencies when --fix is set.
+func fixPR(ctx context.Context, prEngine *engine.PREngine, report *types.PRReport) error {
+	if !prFix {
+		return nil
+	}
+
+	result, err := prEngine.ProposeFixes(ctx, report)
+	if err != nil {
+		return fmt.Errorf("failed to propose fixes: %w", err)
+	}
+	return handleFixes(result, prFixOpts, os.Stdin, os.Stdout, os.Stderr)
+}
+
+func runPRGitHub(cmd *cobra.Command) error {
 	if prNumber == 0 {
 		return fmt.Errorf("--pr flag is required in GitHub mode")
 	}
@@ -204,7 +274,8 @@ func runPRGitHub() error {
 		return fmt.Errorf("GitHub token required: use --token or set GITHUB_TOKEN env")
 	}
 
-	fmt.Printf("Checking PR #%d...\n\n", prNumber)
+	progress := progressWriter(prFormat)
+	fmt.Fprintf(progress, "Checking PR #%d...\n\n", prNumber)
 
 	ghClient, err := github.NewClient(token, prRepo)
 	if err != nil {
@@ -215,8 +286,8 @@ func runPRGitHub() error {
 	if err != nil {
 		return fmt.Errorf("failed to get PR info: %w", err)
 	}
-	fmt.Printf("PR: %s\n", prInfo.Title)
-	fmt.Printf("Base: %s <- Head: %s\n\n", prInfo.BaseBranch, prInfo.HeadBranch)
+	fmt.Fprintf(progress, "PR: %s\n", prInfo.Title)
+	fmt.Fprintf(progress, "Base: %s <- Head: %s\n\n", prInfo.BaseBranch, prInfo.HeadBranch)
 
 	files, err := ghClient.GetPRFiles(prNumber)
 	if err != nil {
@@ -229,118 +300,133 @@ func runPRGitHub() error {
 		diff = github.BuildDiffFromFiles(files)
 	}
 	if diff == "" {
-		fmt.Println("No changes detected")
+		fmt.Fprintln(progress, "No changes detected")
 		return nil
 	}
 
-	extractor := git.NewSymbolExtractor()
+	extractor := git.NewSymbolExtractor(git.NewGitSources(prInfo.BaseBranch))
 	symbols, err := extractor.ExtractChangedSymbols(diff)
 	if err != nil {
 		return fmt.Errorf("failed to extract symbols: %w", err)
 	}
 
 	if len(symbols) == 0 {
-		fmt.Println("No Go symbol changes detected")
+		fmt.Fprintln(progress, "No Go symbol changes detected")
 		return nil
 	}
 
-	fmt.Printf("Found %d changed symbol(s)\n", len(symbols))
+	fmt.Fprintf(progress, "Found %d changed symbol(s)\n", len(symbols))
 
-	segments, err := scanner.ScanMarkdownDir(".", prDocs)
+	cfg := loadPRConfig(progress)
+	applyBaselineFlag(cmd, cfg, prBaseline)
+
+	segments, err := scanner.ScanMarkdownDir(".", prDocs, docScanOptions(cfg))
 	if err != nil {
 		return fmt.Errorf("failed to scan documents: %w", err)
 	}
-	fmt.Printf("Found %d document segments\n", len(segments))
+	fmt.Fprintf(progress, "Found %d document segments\n", len(segments))
+
+	relevantPairs := declaredMatches(symbols, segments)
+	fmt.Fprintf(progress, "Found %d potential matches\n\n", len(relevantPairs))
 
-	relevantPairs := matcher.QuickMatch(symbols, segments)
-	fmt.Printf("Found %d potential matches\n\n", len(relevantPairs))
+	ctx, cancel := signalContext()
+	defer cancel()
 
 	// Use LLM for consistency check if configured
 	var report *types.PRReport
-	if !prSkipLLM {
-		cfg, err := config.Load(cfgFile)
-		if err == nil && cfg.LLM.APIKey != "" {
-			ctx := context.Background()
-			prEngine, err := engine.NewPREngine(cfg)
-			if err != nil {
-				return fmt.Errorf("failed to create PR engine: %w", err)
-			}
+	var prEngine *engine.PREngine
+	if !prSkipLLM && llmConfigured(cfg) {
+		if prConcurrency > 0 {
+			cfg.LLM.MaxConcurrency = prConcurrency
+		}
 
-			opts := engine.PRCheckOptions{
-				BaseBranch:  prInfo.BaseBranch,
-				DocPatterns: prDocs,
-				SkipLLM:     prSkipLLM,
-				UseTwoStage: prTwoStage,
-			}
+		prEngine, err = engine.NewPREngine(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create PR engine: %w", err)
+		}
 
-			report, err = prEngine.CheckFromDiff(ctx, diff, opts)
-			if err != nil {
-				return fmt.Errorf("failed to check: %w", err)
-			}
-		} else {
-			// Fallback to keyword matching only
-			report = &types.PRReport{
-				TotalSymbols:  len(symbols),
-				TotalSegments: len(segments),
-				RelevantPairs: len(relevantPairs),
-			}
+		opts := engine.PRCheckOptions{
+			BaseBranch:  prInfo.BaseBranch,
+			DocPatterns: prDocs,
+			SkipLLM:     prSkipLLM,
+			UseTwoStage: prTwoStage,
+		}
 
-			for _, pair := range relevantPairs {
-				report.Results = append(report.Results, types.PRCheckResult{
-					Segment:    pair.Segment,
-					Symbol:     pair.Symbol,
-					Consistent: true,
-					Confidence: pair.Confidence,
-					Reason:     pair.Reason,
-				})
-			}
+		report, err = prEngine.CheckFromDiff(ctx, diff, opts)
+		if err != nil {
+			return fmt.Errorf("failed to check: %w", err)
 		}
 	} else {
-		// Skip LLM, use keyword matching only
-		report = &types.PRReport{
-			TotalSymbols:  len(symbols),
-			TotalSegments: len(segments),
-			RelevantPairs: len(relevantPairs),
-		}
-
-		for _, pair := range relevantPairs {
-			report.Results = append(report.Results, types.PRCheckResult{
-				Segment:    pair.Segment,
-				Symbol:     pair.Symbol,
-				Consistent: true,
-				Confidence: pair.Confidence,
-				Reason:     pair.Reason,
-			})
+		// Without an LLM, only literals removed by the change are checked.
+		report, err = engine.OfflineReport(cfg, symbols, segments, relevantPairs)
+		if err != nil {
+			return fmt.Errorf("failed to check: %w", err)
 		}
 	}
-
 	if prComment {
 		repoURL := fmt.Sprintf("https://github.com/%s/%s", ghClient.GetOwner(), ghClient.GetRepo())
 		commentBody := reporter.FormatPRComment(report, repoURL)
 
 		existingID, _ := ghClient.FindExistingComment(prNumber)
 		if existingID > 0 {
-			fmt.Println("Updating existing comment...")
+			fmt.Fprintln(progress, "Updating existing comment...")
 			if err := ghClient.UpdateComment(existingID, commentBody); err != nil {
 				return fmt.Errorf("failed to update comment: %w", err)
 			}
 		} else {
-			fmt.Println("Creating comment...")
+			fmt.Fprintln(progress, "Creating comment...")
 			if err := ghClient.CreateComment(prNumber, commentBody); err != nil {
 				return fmt.Errorf("failed to create comment: %w", err)
 			}
 		}
-		fmt.Println("Comment posted successfully")
+		fmt.Fprintln(progress, "Comment posted successfully")
 	}
 
-	if prFormat == "json" {
-		return outputReportJSON(report)
+	if err := outputReport(report, prFormat); err != nil {
+		return err
 	}
-	outputReportText(report)
-
+	if prFix && prEngine == nil {
+		return fmt.Errorf("--fix needs a configured LLM")
+	}
+	if err := fixPR(ctx, prEngine, report); err != nil {
+		return err
+	}
+	exitOnBlocking(cfg, report)
 	return nil
 }
 
+// loadPRConfig loads the configuration, falling back to the defaults so that
+// checks without an LLM still work when the config file cannot be read.
+func loadPRConfig(progress io.Writer) *config.Config {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(progress, "Warning: failed to load config, using defaults: %v\n", err)
+		cfg = config.Default()
+		applyGlobalFlags(cfg)
+	}
+	return cfg
+}
+
+// docScanOptions returns the scan.exclude and scan.gitignore settings used with --docs.
+func docScanOptions(cfg *config.Config) glob.Options {
+	return glob.Options{Exclude: cfg.Scan.Exclude, GitIgnore: cfg.Scan.GitIgnore}
+}
+
+// llmConfigured reports whether the configured provider has what it needs to run.
+// Ollama runs locally and replay reads a transcript; neither needs an API key.
+func llmConfigured(cfg *config.Config) bool {
+	return cfg.LLM.Provider == "ollama" || cfg.LLM.Provider == "replay" || cfg.LLM.APIKey != ""
+}
+
+// progressWriter returns where progress messages go. Machine-readable
+// formats keep stdout clean so the report can be redirected to a file.
+func progressWriter(format string) io.Writer {
+	if format == "json" || format == "sarif" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 func outputSymbolsText(symbols []types.ChangedSymbol, printer *ui.Printer) {
 	printer.Success("Found %d changed symbol(s):", len(symbols))
 	fmt.Println()
@@ -370,52 +456,37 @@ func outputSymbolsJSON(symbols []types.ChangedSymbol) error {
 	})
 }
 
-func outputRelevanceText(pairs []types.RelevanceResult, printer *ui.Printer) {
-	printer.Info("Documentation that may need review:")
-	fmt.Println()
-
-	for i, pair := range pairs {
-		fmt.Printf("%d. %s <-> %s\n", i+1, ui.Highlight(pair.Segment.Heading), ui.Highlight(pair.Symbol.Name))
-		fmt.Printf("   Doc: %s (L%d-%d)\n", ui.Dim(pair.Segment.File), pair.Segment.StartLine, pair.Segment.EndLine)
-		fmt.Printf("   Code: %s (L%d-%d)\n", ui.Dim(pair.Symbol.File), pair.Symbol.StartLine, pair.Symbol.EndLine)
-
-		confidence := pair.Confidence * 100
-		confidenceStr := fmt.Sprintf("%.0f%%", confidence)
-		if confidence >= 70 {
-			confidenceStr = ui.Success(confidenceStr)
-		} else if confidence >= 40 {
-			confidenceStr = ui.Warning(confidenceStr)
-		} else {
-			confidenceStr = ui.Dim(confidenceStr)
-		}
-		fmt.Printf("   Confidence: %s\n", confidenceStr)
-		fmt.Println()
-	}
-}
-
-func outputRelevanceJSON(pairs []types.RelevanceResult) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(map[string]interface{}{
-		"matches": pairs,
-		"count":   len(pairs),
-	})
-}
-
-func outputReportText(report *types.PRReport) {
+// outputReportText prints a PR or audit report. symbolsLabel names what
+// report.TotalSymbols counts.
+func outputReportText(report *types.PRReport, symbolsLabel string) {
 	printer := ui.NewPrinter(os.Stdout, false)
 
 	fmt.Println()
 	printer.Info("Summary:")
-	fmt.Printf("  Symbols changed: %s\n", ui.Highlight(fmt.Sprintf("%d", report.TotalSymbols)))
+	fmt.Printf("  %s: %s\n", symbolsLabel, ui.Highlight(fmt.Sprintf("%d", report.TotalSymbols)))
 	fmt.Printf("  Documents scanned: %s\n", ui.Highlight(fmt.Sprintf("%d", report.TotalSegments)))
 	fmt.Printf("  Relevant pairs: %s\n", ui.Highlight(fmt.Sprintf("%d", report.RelevantPairs)))
+	if len(report.Suppressed) > 0 {
+		fmt.Printf("  Suppressed: %s\n", ui.Dim(fmt.Sprintf("%d", len(report.Suppressed))))
+	}
 
 	if report.Inconsistent > 0 {
-		fmt.Printf("  Inconsistent: %s\n", ui.Error(fmt.Sprintf("%d", report.Inconsistent)))
+		fmt.Printf("  Inconsistent: %s (%d blocking)\n", ui.Error(fmt.Sprintf("%d", report.Inconsistent)), report.Blocking)
 	} else {
 		fmt.Printf("  Inconsistent: %s\n", ui.Success("0"))
 	}
+	if report.Baselined > 0 {
+		fmt.Printf("  Baselined: %s\n", ui.Dim(fmt.Sprintf("%d", report.Baselined)))
+	}
+	if report.Errors > 0 {
+		fmt.Printf("  Errors: %s\n", ui.Error(fmt.Sprintf("%d", report.Errors)))
+	}
+	if report.Skipped > 0 {
+		fmt.Printf("  Skipped: %s\n", ui.Warning(fmt.Sprintf("%d (LLM budget exceeded)", report.Skipped)))
+	}
+	if report.Usage != nil {
+		fmt.Printf("  LLM usage: %s\n", ui.Dim(reporter.FormatUsage(*report.Usage)))
+	}
 	fmt.Printf("  Time: %s\n", ui.Dim(fmt.Sprintf("%dms", report.ExecutionTimeMs)))
 
 	if report.Inconsistent > 0 {
@@ -423,8 +494,9 @@ func outputReportText(report *types.PRReport) {
 		printer.Warning("Inconsistencies found:")
 		fmt.Println()
 		for _, r := range report.Results {
-			if !r.Consistent {
-				fmt.Printf("  - %s <-> %s\n", ui.Highlight(r.Segment.Heading), ui.Highlight(r.Symbol.Name))
+			if !r.Consistent && !r.Baselined && r.Status != types.StatusError {
+				fmt.Printf("  - [%s] %s <-> %s\n", r.Severity, ui.Highlight(r.Segment.Heading), ui.Highlight(r.Symbol.Name))
+				fmt.Printf("    %s: %s\n", ui.Dim("Doc"), ui.Dim(fmt.Sprintf("%s:%d", r.Segment.File, reporter.DocLine(r))))
 				fmt.Printf("    %s: %s\n", ui.Error("Reason"), r.Reason)
 				if r.Suggestion != "" {
 					fmt.Printf("    %s: %s\n", ui.Info("Suggestion"), r.Suggestion)
@@ -433,6 +505,40 @@ func outputReportText(report *types.PRReport) {
 			}
 		}
 	}
+
+	if report.Errors > 0 {
+		fmt.Println()
+		printer.Error("Checks that could not be completed:")
+		fmt.Println()
+		for _, r := range report.Results {
+			if r.Status == types.StatusError {
+				fmt.Printf("  - %s <-> %s\n", ui.Highlight(r.Segment.Heading), ui.Highlight(r.Symbol.Name))
+				fmt.Printf("    %s: %s\n", ui.Error("Error"), r.Error)
+				fmt.Println()
+			}
+		}
+	}
+
+	if len(report.Suppressed) > 0 {
+		fmt.Println()
+		printer.Info("Suppressed by docuguard:ignore:")
+		fmt.Println()
+		for _, s := range report.Suppressed {
+			fmt.Printf("  - %s <-> %s (%s)\n", ui.Highlight(s.Heading), ui.Highlight(s.Symbol), ui.Dim(fmt.Sprintf("%s:%d", s.Suppression.File, s.Suppression.Line)))
+			if s.Suppression.Reason != "" {
+				fmt.Printf("    %s: %s\n", ui.Dim("Reason"), s.Suppression.Reason)
+			}
+		}
+	}
+
+	if len(report.StaleBaseline) > 0 {
+		fmt.Println()
+		printer.Warning("Baseline entries that no longer reproduce (run 'docuguard baseline create' to refresh):")
+		fmt.Println()
+		for _, e := range report.StaleBaseline {
+			fmt.Printf("  - %s <-> %s (%s)\n", ui.Highlight(e.Heading), ui.Highlight(e.Symbol), ui.Dim(e.DocFile))
+		}
+	}
 }
 
 func outputReportJSON(report *types.PRReport) error {
@@ -441,6 +547,22 @@ func outputReportJSON(report *types.PRReport) error {
 	return enc.Encode(report)
 }
 
+func outputReport(report *types.PRReport, format string) error {
+	switch format {
+	case "json":
+		return outputReportJSON(report)
+	case "sarif":
+		return outputReportSARIF(report)
+	}
+	outputReportText(report, "Symbols changed")
+	return nil
+}
+
+func outputReportSARIF(report *types.PRReport) error {
+	rep := &reporter.SARIFReporter{ToolVersion: Version}
+	return rep.ReportPR(os.Stdout, report)
+}
+
 func getChangeIcon(ct types.ChangeType) string {
 	switch ct {
 	case types.ChangeAdded:
@@ -449,6 +571,8 @@ func getChangeIcon(ct types.ChangeType) string {
 		return "M"
 	case types.ChangeDeleted:
 		return "-"
+	case types.ChangeNone:
+		return "="
 	default:
 		return "?"
 	}
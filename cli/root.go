@@ -1,15 +1,24 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
 )
 
 var (
 	cfgFile string
 	verbose bool
+	record  bool
+
+	maxTokens int
+	maxCost   float64
 )
 
 var rootCmd = &cobra.Command{
@@ -36,4 +45,36 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path (default: .docuguard.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVar(&record, "record", false, "record LLM requests and responses to llm.transcript for replay")
+	rootCmd.PersistentFlags().IntVar(&maxTokens, "max-tokens", 0, "stop LLM calls after this many tokens; remaining pairs are skipped (default: llm.budget.max_tokens)")
+	rootCmd.PersistentFlags().Float64Var(&maxCost, "max-cost", 0, "stop LLM calls after this estimated cost in USD; needs llm.prices (default: llm.budget.max_cost)")
+}
+
+// loadConfig reads the configuration file and applies the global flags.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	applyGlobalFlags(cfg)
+	return cfg, nil
+}
+
+// applyGlobalFlags overrides configuration with flags shared by all commands.
+func applyGlobalFlags(cfg *config.Config) {
+	if record {
+		cfg.LLM.Record = true
+	}
+	if maxTokens > 0 {
+		cfg.LLM.Budget.MaxTokens = maxTokens
+	}
+	if maxCost > 0 {
+		cfg.LLM.Budget.MaxCost = maxCost
+	}
+}
+
+// signalContext returns a context that is canceled on Ctrl-C or SIGTERM,
+// so in-flight LLM calls stop and no new ones are started.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 }
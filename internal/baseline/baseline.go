@@ -0,0 +1,196 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// DefaultFile is the baseline path used when none is configured.
+const DefaultFile = ".docuguard-baseline.json"
+
+// fileVersion is the current baseline file format version.
+const fileVersion = 1
+
+// Baseline is the content of a baseline file.
+type Baseline struct {
+	Version int                   `json:"version"`
+	Entries []types.BaselineEntry `json:"entries"`
+}
+
+// Load reads a baseline file. A missing file yields a nil baseline and no error.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if b.Version > fileVersion {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d", path, b.Version)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline as indented JSON.
+func (b *Baseline) Save(path string) error {
+	b.Version = fileVersion
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Finding identifies a doc/code pair and, for inconsistencies, the reason.
+type Finding struct {
+	DocFile string
+	Heading string
+	Symbol  string
+	Reason  string
+}
+
+// NewEntry creates a baseline entry for a finding.
+func NewEntry(f Finding) types.BaselineEntry {
+	return types.BaselineEntry{
+		Fingerprint: Fingerprint(f),
+		DocFile:     normalizePath(f.DocFile),
+		Heading:     f.Heading,
+		Symbol:      f.Symbol,
+		Reason:      f.Reason,
+	}
+}
+
+// Fingerprint hashes the doc file, heading, symbol and normalized reason.
+func Fingerprint(f Finding) string {
+	h := sha256.New()
+	for _, part := range []string{normalizePath(f.DocFile), f.Heading, f.Symbol, normalizeReason(f.Reason)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Add records a finding unless an identical entry exists.
+func (b *Baseline) Add(f Finding) {
+	entry := NewEntry(f)
+	for _, e := range b.Entries {
+		if e.Fingerprint == entry.Fingerprint {
+			return
+		}
+	}
+	b.Entries = append(b.Entries, entry)
+}
+
+// FromReport creates a baseline of the inconsistencies in a check report.
+func FromReport(report *types.Report) *Baseline {
+	b := &Baseline{Entries: []types.BaselineEntry{}}
+	for _, r := range report.Results {
+		if !r.Consistent {
+			b.Add(Finding{DocFile: r.DocLoc.File, Heading: r.Heading, Symbol: r.CodeLoc.Symbol, Reason: r.Reason})
+		}
+	}
+	return b
+}
+
+// FromPRReport creates a baseline of the inconsistencies in a PR report.
+// Failed checks are not findings and are skipped.
+func FromPRReport(report *types.PRReport) *Baseline {
+	b := &Baseline{Entries: []types.BaselineEntry{}}
+	for _, r := range report.Results {
+		if !r.Consistent && r.Status != types.StatusError {
+			b.Add(Finding{DocFile: r.Segment.File, Heading: r.Segment.Heading, Symbol: r.Symbol.Name, Reason: r.Reason})
+		}
+	}
+	return b
+}
+
+// Match decides which findings are baselined and which entries are stale.
+//
+// A finding matches an entry with the same fingerprint. Since LLM wording
+// varies between runs, a finding left unmatched then takes an unused entry
+// for the same doc file, heading and symbol. Entries matching no finding are
+// stale if their pair appears in checked, i.e. it was checked and found
+// consistent; pairs not checked in this run say nothing about the entry.
+func (b *Baseline) Match(findings, checked []Finding) (baselined []bool, stale []types.BaselineEntry) {
+	baselined = make([]bool, len(findings))
+	if b == nil {
+		return baselined, nil
+	}
+
+	used := make([]bool, len(b.Entries))
+	byFingerprint := make(map[string][]int)
+	for i, e := range b.Entries {
+		byFingerprint[e.Fingerprint] = append(byFingerprint[e.Fingerprint], i)
+	}
+
+	for i, f := range findings {
+		if idx, ok := byFingerprint[Fingerprint(f)]; ok {
+			baselined[i] = true
+			for _, j := range idx {
+				used[j] = true
+			}
+		}
+	}
+
+	for i, f := range findings {
+		if baselined[i] {
+			continue
+		}
+		loc := location(f)
+		for j, e := range b.Entries {
+			if !used[j] && entryLocation(e) == loc {
+				used[j] = true
+				baselined[i] = true
+				break
+			}
+		}
+	}
+
+	inScope := make(map[string]bool, len(checked))
+	for _, c := range checked {
+		inScope[location(c)] = true
+	}
+	for j, e := range b.Entries {
+		if !used[j] && inScope[entryLocation(e)] {
+			stale = append(stale, e)
+		}
+	}
+
+	return baselined, stale
+}
+
+func location(f Finding) string {
+	return normalizePath(f.DocFile) + "\x00" + f.Heading + "\x00" + f.Symbol
+}
+
+func entryLocation(e types.BaselineEntry) string {
+	return location(Finding{DocFile: e.DocFile, Heading: e.Heading, Symbol: e.Symbol})
+}
+
+func normalizePath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// normalizeReason lowercases the reason and reduces it to words, so
+// punctuation and spacing differences do not change the fingerprint.
+func normalizeReason(reason string) string {
+	words := strings.FieldsFunc(strings.ToLower(reason), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,70 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintNormalizesReason(t *testing.T) {
+	a := Finding{DocFile: "./docs/api.md", Heading: "Limits", Symbol: "MaxRetries", Reason: "Doc says 5 retries, code uses 3."}
+	b := Finding{DocFile: "docs/api.md", Heading: "Limits", Symbol: "MaxRetries", Reason: "doc says 5 retries;  code uses 3"}
+	assert.Equal(t, Fingerprint(a), Fingerprint(b))
+
+	b.Heading = "Retries"
+	assert.NotEqual(t, Fingerprint(a), Fingerprint(b))
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	b, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Nil(t, b)
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	b := &Baseline{}
+	b.Add(Finding{DocFile: "README.md", Symbol: "Timeout", Reason: "doc says 30s"})
+	b.Add(Finding{DocFile: "README.md", Symbol: "Timeout", Reason: "Doc says 30s."})
+	require.Len(t, b.Entries, 1, "duplicate findings are recorded once")
+	require.NoError(t, b.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, fileVersion, loaded.Version)
+	assert.Equal(t, b.Entries, loaded.Entries)
+}
+
+func TestMatch(t *testing.T) {
+	b := &Baseline{}
+	b.Add(Finding{DocFile: "README.md", Heading: "Shipping", Symbol: "CalculateShipping", Reason: "doc says 100, code uses 500"})
+	b.Add(Finding{DocFile: "README.md", Heading: "Retries", Symbol: "MaxRetries", Reason: "doc says 5 retries"})
+	b.Add(Finding{DocFile: "README.md", Heading: "Timeouts", Symbol: "Timeout", Reason: "doc says 30s"})
+	b.Add(Finding{DocFile: "docs/other.md", Heading: "Other", Symbol: "Other", Reason: "not checked"})
+
+	findings := []Finding{
+		// Same reason with different punctuation.
+		{DocFile: "./README.md", Heading: "Shipping", Symbol: "CalculateShipping", Reason: "Doc says 100; code uses 500."},
+		// Reworded by the model, same location.
+		{DocFile: "README.md", Heading: "Retries", Symbol: "MaxRetries", Reason: "documentation claims five retries"},
+		// New finding.
+		{DocFile: "README.md", Heading: "Cache", Symbol: "CacheSize", Reason: "doc says 1 MB"},
+	}
+	checked := append([]Finding{
+		{DocFile: "README.md", Heading: "Timeouts", Symbol: "Timeout"},
+	}, findings...)
+
+	baselined, stale := b.Match(findings, checked)
+	assert.Equal(t, []bool{true, true, false}, baselined)
+	require.Len(t, stale, 1, "entries for pairs not checked in this run are not stale")
+	assert.Equal(t, "Timeout", stale[0].Symbol)
+}
+
+func TestMatchNilBaseline(t *testing.T) {
+	var b *Baseline
+	baselined, stale := b.Match([]Finding{{DocFile: "README.md"}}, nil)
+	assert.Equal(t, []bool{false}, baselined)
+	assert.Empty(t, stale)
+}
@@ -0,0 +1,7 @@
+// Package baseline records known findings so they do not fail later runs.
+//
+// A baseline file lists fingerprints of inconsistencies that were accepted
+// when DocuGuard was adopted. Matching findings are reported separately
+// instead of counting as inconsistent, and entries whose doc/code pair was
+// checked again without reproducing the finding are flagged as stale.
+package baseline
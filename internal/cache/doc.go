@@ -0,0 +1,6 @@
+// Package cache provides a content-addressed on-disk store for LLM verdicts.
+//
+// Entries are keyed by a hash of everything that influences a response
+// (provider, model, prompt version and the analyzed content), so unchanged
+// doc/code pairs can reuse prior results across runs.
+package cache
@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultDir is the cache location relative to the project root.
+const DefaultDir = ".docuguard/cache"
+
+// Store is a directory of JSON entries addressed by content hash.
+type Store struct {
+	dir string
+}
+
+// Stats summarizes the contents of a Store.
+type Stats struct {
+	Entries   int       `json:"entries"`
+	SizeBytes int64     `json:"size_bytes"`
+	Oldest    time.Time `json:"oldest,omitempty"`
+	Newest    time.Time `json:"newest,omitempty"`
+}
+
+// NewStore returns a Store rooted at dir. The directory is created lazily on first write.
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return &Store{dir: dir}
+}
+
+// Dir returns the root directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Key hashes the given parts into a cache key. Parts are length-prefixed so
+// that different splits of the same bytes never collide.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%d:", len(p))
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get decodes the entry for key into v. It reports false when no entry exists.
+// A hit refreshes the entry's modification time so Prune keeps recently used entries.
+func (s *Store) Get(key string, v interface{}) (bool, error) {
+	path := s.path(key)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("corrupt cache entry %s: %w", key, err)
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return true, nil
+}
+
+// Put stores v under key, replacing any existing entry.
+func (s *Store) Put(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename so concurrent readers never see partial entries.
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Stats walks the store and summarizes its entries.
+func (s *Store) Stats() (Stats, error) {
+	var st Stats
+	err := s.walk(func(path string, info fs.FileInfo) error {
+		st.Entries++
+		st.SizeBytes += info.Size()
+		mod := info.ModTime()
+		if st.Oldest.IsZero() || mod.Before(st.Oldest) {
+			st.Oldest = mod
+		}
+		if mod.After(st.Newest) {
+			st.Newest = mod
+		}
+		return nil
+	})
+	return st, err
+}
+
+// Clear removes every entry and returns how many were deleted.
+func (s *Store) Clear() (int, error) {
+	return s.Prune(0)
+}
+
+// Prune removes entries not used within maxAge and returns how many were deleted.
+// A zero maxAge removes everything.
+func (s *Store) Prune(maxAge time.Duration) (int, error) {
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+	err := s.walk(func(path string, info fs.FileInfo) error {
+		if maxAge > 0 && info.ModTime().After(cutoff) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// walk calls fn for every entry file in the store. A missing store is empty.
+func (s *Store) walk(fn func(path string, info fs.FileInfo) error) error {
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(path, info)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path shards entries by the first two hex digits to keep directories small.
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key[:2], key+".json")
+}
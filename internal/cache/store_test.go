@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PutGet(t *testing.T) {
+	store := NewStore(t.TempDir())
+	key := Key("openai", "gpt-4", "doc", "code")
+
+	var got map[string]string
+	hit, err := store.Get(key, &got)
+	require.NoError(t, err)
+	assert.False(t, hit)
+
+	require.NoError(t, store.Put(key, map[string]string{"reason": "ok"}))
+
+	hit, err = store.Get(key, &got)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, "ok", got["reason"])
+
+	st, err := store.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.Entries)
+	assert.Greater(t, st.SizeBytes, int64(0))
+}
+
+func TestKey_LengthPrefixed(t *testing.T) {
+	assert.NotEqual(t, Key("ab", "c"), Key("a", "bc"))
+	assert.Equal(t, Key("a", "b"), Key("a", "b"))
+}
+
+func TestStore_Prune(t *testing.T) {
+	store := NewStore(t.TempDir())
+	oldKey := Key("old")
+	newKey := Key("new")
+	require.NoError(t, store.Put(oldKey, 1))
+	require.NoError(t, store.Put(newKey, 2))
+
+	past := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(store.path(oldKey), past, past))
+
+	removed, err := store.Prune(24 * time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	var v int
+	hit, _ := store.Get(newKey, &v)
+	assert.True(t, hit)
+
+	removed, err = store.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestStore_MissingDir(t *testing.T) {
+	store := NewStore(t.TempDir() + "/missing")
+	st, err := store.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 0, st.Entries)
+}
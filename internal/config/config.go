@@ -4,26 +4,55 @@ import "time"
 
 // Config 应用配置
 type Config struct {
-	Version string     `mapstructure:"version"`
-	LLM     LLMConfig  `mapstructure:"llm"`
-	Scan    ScanConfig `mapstructure:"scan"`
-	Rules   RuleConfig `mapstructure:"rules"`
-	Output  OutConfig  `mapstructure:"output"`
+	Version string      `mapstructure:"version"`
+	LLM     LLMConfig   `mapstructure:"llm"`
+	Scan    ScanConfig  `mapstructure:"scan"`
+	Rules   RuleConfig  `mapstructure:"rules"`
+	Output  OutConfig   `mapstructure:"output"`
+	Cache   CacheConfig `mapstructure:"cache"`
 }
 
 // LLMConfig LLM 配置
 type LLMConfig struct {
-	Provider string        `mapstructure:"provider"` // openai, anthropic, ollama
-	Model    string        `mapstructure:"model"`
-	APIKey   string        `mapstructure:"api_key"`
-	BaseURL  string        `mapstructure:"base_url"`
-	Timeout  time.Duration `mapstructure:"timeout"`
+	Provider       string                 `mapstructure:"provider"` // openai, anthropic, ollama, replay
+	Model          string                 `mapstructure:"model"`
+	APIKey         string                 `mapstructure:"api_key"`
+	BaseURL        string                 `mapstructure:"base_url"`
+	Timeout        time.Duration          `mapstructure:"timeout"`
+	Retry          RetryConfig            `mapstructure:"retry"`
+	MaxConcurrency int                    `mapstructure:"max_concurrency"` // parallel LLM requests
+	ContextTokens  int                    `mapstructure:"context_tokens"`  // model context window, 0 uses the known size of the model
+	Transcript     string                 `mapstructure:"transcript"`      // recorded requests, served by provider replay
+	Record         bool                   `mapstructure:"record"`          // write requests and responses to the transcript
+	Prices         map[string]PriceConfig `mapstructure:"prices"`          // by model name prefix, for the cost in reports
+	Budget         BudgetConfig           `mapstructure:"budget"`          // per-run usage limits
+}
+
+// PriceConfig LLM 价格，单位为美元/百万 token
+type PriceConfig struct {
+	Prompt     float64 `mapstructure:"prompt"`
+	Completion float64 `mapstructure:"completion"`
+}
+
+// BudgetConfig 单次运行的 LLM 用量上限，0 表示不限
+type BudgetConfig struct {
+	MaxTokens int     `mapstructure:"max_tokens"` // prompt and completion tokens
+	MaxCost   float64 `mapstructure:"max_cost"`   // estimated USD, needs a price for the model
+}
+
+// RetryConfig LLM 请求重试配置
+type RetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // total attempts, 1 disables retries
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // delay before the first retry
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // cap for exponential backoff
 }
 
 // ScanConfig 扫描配置
 type ScanConfig struct {
-	Include []string `mapstructure:"include"`
-	Exclude []string `mapstructure:"exclude"`
+	Include   []string `mapstructure:"include"`
+	Exclude   []string `mapstructure:"exclude"`
+	GitIgnore bool     `mapstructure:"gitignore"` // skip files ignored by .gitignore
+	Code      []string `mapstructure:"code"`      // Go sources searched for //docuguard:doc directives
 }
 
 // RuleConfig 规则配置
@@ -31,6 +60,14 @@ type RuleConfig struct {
 	FailOnInconsistent  bool    `mapstructure:"fail_on_inconsistent"`
 	SeverityThreshold   string  `mapstructure:"severity_threshold"`
 	ConfidenceThreshold float64 `mapstructure:"confidence_threshold"`
+	Baseline            string  `mapstructure:"baseline"` // known findings that do not fail the run
+}
+
+// CacheConfig LLM 响应缓存配置
+type CacheConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Dir     string        `mapstructure:"dir"`
+	MaxAge  time.Duration `mapstructure:"max_age"` // default age for `docuguard cache prune`
 }
 
 // OutConfig 输出配置
@@ -44,33 +44,93 @@ func Load(configPath string) (*Config, error) {
 		cfg.LLM.APIKey = os.Getenv(envVar)
 	}
 
-	if cfg.LLM.APIKey == "" {
-		cfg.LLM.APIKey = os.Getenv("OPENAI_API_KEY")
+	if cfg.LLM.APIKey == "" && cfg.LLM.Provider == "anthropic" {
+		cfg.LLM.APIKey = os.Getenv("ANTHROPIC_API_KEY")
 	}
 
-	if cfg.LLM.BaseURL == "" {
-		if baseURL := os.Getenv("OPENAI_API_BASE"); baseURL != "" {
-			cfg.LLM.BaseURL = baseURL
+	// OPENAI_* credentials, endpoint and model overrides would point other
+	// providers at the wrong API.
+	if openAICompatible(cfg.LLM.Provider) {
+		if cfg.LLM.APIKey == "" {
+			cfg.LLM.APIKey = os.Getenv("OPENAI_API_KEY")
+		}
+
+		if cfg.LLM.BaseURL == "" {
+			if baseURL := os.Getenv("OPENAI_API_BASE"); baseURL != "" {
+				cfg.LLM.BaseURL = baseURL
+			}
 		}
-	}
 
-	if envModel := os.Getenv("OPENAI_MODEL"); envModel != "" {
-		cfg.LLM.Model = envModel
+		if envModel := os.Getenv("OPENAI_MODEL"); envModel != "" {
+			cfg.LLM.Model = envModel
+		}
 	}
 
+	setDefaultModel(&cfg)
 	return &cfg, nil
 }
 
+// Default returns the configuration used when no config file is present,
+// without reading the environment.
+func Default() *Config {
+	v := viper.New()
+	setDefaults(v)
+
+	var cfg Config
+	_ = v.Unmarshal(&cfg)
+	setDefaultModel(&cfg)
+	return &cfg
+}
+
+// openAICompatible reports whether provider is served by the OpenAI client,
+// which handles "openai" and every provider without a client of its own.
+func openAICompatible(provider string) bool {
+	switch provider {
+	case "anthropic", "ollama", "replay":
+		return false
+	}
+	return true
+}
+
+// setDefaultModel fills in the default model of the configured provider.
+// Ollama serves whatever models were pulled, so it has none, and replayed
+// responses need none.
+func setDefaultModel(cfg *Config) {
+	if cfg.LLM.Model != "" {
+		return
+	}
+	switch {
+	case cfg.LLM.Provider == "anthropic":
+		cfg.LLM.Model = "claude-sonnet-4-5"
+	case openAICompatible(cfg.LLM.Provider):
+		cfg.LLM.Model = "gpt-4"
+	}
+}
+
 func setDefaults(v *viper.Viper) {
 	v.SetDefault("version", "1.0")
 	v.SetDefault("llm.provider", "openai")
-	v.SetDefault("llm.model", "gpt-4")
+	v.SetDefault("llm.model", "")
 	v.SetDefault("llm.timeout", "30s")
+	v.SetDefault("llm.max_concurrency", 4)
+	v.SetDefault("llm.context_tokens", 0)
+	v.SetDefault("llm.retry.max_attempts", 3)
+	v.SetDefault("llm.retry.initial_backoff", "1s")
+	v.SetDefault("llm.retry.max_backoff", "30s")
+	v.SetDefault("llm.transcript", ".docuguard/transcript.json")
+	v.SetDefault("llm.budget.max_tokens", 0)
+	v.SetDefault("llm.budget.max_cost", 0.0)
 	v.SetDefault("scan.include", []string{"**/*.md"})
 	v.SetDefault("scan.exclude", []string{})
+	v.SetDefault("scan.gitignore", true)
+	v.SetDefault("scan.code", []string{"**/*.go"})
 	v.SetDefault("rules.fail_on_inconsistent", true)
 	v.SetDefault("rules.severity_threshold", "warning")
 	v.SetDefault("rules.confidence_threshold", 0.8)
+	v.SetDefault("rules.baseline", ".docuguard-baseline.json")
 	v.SetDefault("output.format", "text")
 	v.SetDefault("output.color", true)
+	v.SetDefault("cache.enabled", true)
+	v.SetDefault("cache.dir", ".docuguard/cache")
+	v.SetDefault("cache.max_age", "720h")
 }
@@ -37,3 +37,44 @@ func TestLoad_EnvOverride(t *testing.T) {
 		t.Errorf("expected API key 'test-key', got '%s'", cfg.LLM.APIKey)
 	}
 }
+
+func TestLoad_AnthropicIgnoresOpenAI(t *testing.T) {
+	os.Setenv("DOCUGUARD_LLM_PROVIDER", "anthropic")
+	defer os.Unsetenv("DOCUGUARD_LLM_PROVIDER")
+	os.Setenv("OPENAI_API_KEY", "openai-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+	os.Unsetenv("ANTHROPIC_API_KEY")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.LLM.APIKey != "" {
+		t.Errorf("expected no API key, got '%s'", cfg.LLM.APIKey)
+	}
+
+	if cfg.LLM.Model != "claude-sonnet-4-5" {
+		t.Errorf("expected model 'claude-sonnet-4-5', got '%s'", cfg.LLM.Model)
+	}
+}
+
+func TestLoad_OpenAICompatibleProvider(t *testing.T) {
+	os.Setenv("DOCUGUARD_LLM_PROVIDER", "siliconflow")
+	defer os.Unsetenv("DOCUGUARD_LLM_PROVIDER")
+	os.Setenv("OPENAI_API_KEY", "openai-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.LLM.APIKey != "openai-key" {
+		t.Errorf("expected API key 'openai-key', got '%s'", cfg.LLM.APIKey)
+	}
+
+	if cfg.LLM.Model != "gpt-4" {
+		t.Errorf("expected model 'gpt-4', got '%s'", cfg.LLM.Model)
+	}
+}
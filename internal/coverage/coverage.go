@@ -0,0 +1,107 @@
+package coverage
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/matcher"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// Report is the documentation coverage of a set of exported symbols.
+type Report struct {
+	// Total is the number of symbols counted, excluding ignored ones.
+	Total      int `json:"total"`
+	Documented int `json:"documented"`
+	// Ignored is the number of symbols marked with //docuguard:ignore.
+	Ignored int `json:"ignored"`
+	// Coverage is the percentage of documented symbols.
+	Coverage float64   `json:"coverage"`
+	Packages []Package `json:"packages"`
+}
+
+// Package is the coverage of the symbols declared in one directory.
+type Package struct {
+	Path         string   `json:"package"`
+	Total        int      `json:"total"`
+	Documented   int      `json:"documented"`
+	Coverage     float64  `json:"coverage"`
+	Undocumented []Symbol `json:"undocumented,omitempty"`
+}
+
+// Symbol is an exported declaration no documentation describes.
+type Symbol struct {
+	Name string            `json:"name"`
+	Type types.BindingType `json:"type"`
+	File string            `json:"file"`
+	Line int               `json:"line"`
+}
+
+// Compute reports which symbols are mentioned by name in the Markdown
+// segments docs. If godoc is not nil, a symbol with its own doc comment among
+// the godoc segments counts as documented too.
+func Compute(symbols []types.ChangedSymbol, docs, godoc []types.DocSegment) *Report {
+	documented := make(map[string]bool)
+	for _, pair := range matcher.NameMatch(symbols, docs) {
+		documented[symbolKey(pair.Symbol.File, pair.Symbol.Name)] = true
+	}
+	for _, seg := range godoc {
+		// Headings read "func Name", "type Name", "const Name" or "var Name".
+		if _, name, ok := strings.Cut(seg.Heading, " "); ok && !strings.HasPrefix(seg.Heading, "Package ") {
+			documented[symbolKey(seg.File, name)] = true
+		}
+	}
+
+	report := &Report{}
+	byPath := make(map[string]*Package)
+	for _, sym := range symbols {
+		if sym.Suppression != nil {
+			report.Ignored++
+			continue
+		}
+
+		dir := path.Dir(filepath.ToSlash(sym.File))
+		pkg, ok := byPath[dir]
+		if !ok {
+			pkg = &Package{Path: dir}
+			byPath[dir] = pkg
+		}
+		pkg.Total++
+		report.Total++
+
+		if documented[symbolKey(sym.File, sym.Name)] {
+			pkg.Documented++
+			report.Documented++
+			continue
+		}
+		pkg.Undocumented = append(pkg.Undocumented, Symbol{
+			Name: sym.Name,
+			Type: sym.Type,
+			File: sym.File,
+			Line: sym.StartLine,
+		})
+	}
+
+	for _, pkg := range byPath {
+		pkg.Coverage = percent(pkg.Documented, pkg.Total)
+		report.Packages = append(report.Packages, *pkg)
+	}
+	sort.Slice(report.Packages, func(i, j int) bool { return report.Packages[i].Path < report.Packages[j].Path })
+	report.Coverage = percent(report.Documented, report.Total)
+	return report
+}
+
+// symbolKey identifies a symbol by its file and qualified name.
+func symbolKey(file, name string) string {
+	return filepath.ToSlash(filepath.Clean(file)) + ":" + name
+}
+
+// percent returns part/total as a percentage; nothing to document is full coverage.
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(part) * 100 / float64(total)
+}
@@ -0,0 +1,49 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestCompute(t *testing.T) {
+	symbols := []types.ChangedSymbol{
+		{File: "shop/shop.go", Name: "CalculateShipping", Type: types.BindingFunc, StartLine: 3},
+		{File: "shop/shop.go", Name: "FreeShippingThreshold", Type: types.BindingConst, StartLine: 10},
+		{File: "shop/cart.go", Name: "Cart.Total", Type: types.BindingFunc, StartLine: 7},
+		{File: "shop/cart.go", Name: "Legacy", Type: types.BindingFunc, StartLine: 12,
+			Suppression: &types.Suppression{Symbol: "Legacy"}},
+		{File: "api/api.go", Name: "Serve", Type: types.BindingFunc, StartLine: 5},
+	}
+	docs := []types.DocSegment{
+		{File: "README.md", Heading: "Shipping", Content: "CalculateShipping charges a flat fee. Call cart.Total() first."},
+		{File: "README.md", Heading: "Server", Content: "Serves requests."},
+	}
+	godoc := []types.DocSegment{
+		{File: "shop/shop.go", Heading: "Package shop"},
+		{File: "shop/shop.go", Heading: "const FreeShippingThreshold"},
+	}
+
+	report := Compute(symbols, docs, nil)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 2, report.Documented)
+	assert.Equal(t, 1, report.Ignored)
+	assert.InDelta(t, 50.0, report.Coverage, 0.001)
+
+	require.Len(t, report.Packages, 2)
+	assert.Equal(t, "api", report.Packages[0].Path)
+	assert.InDelta(t, 0.0, report.Packages[0].Coverage, 0.001)
+	assert.Equal(t, []Symbol{{Name: "Serve", Type: types.BindingFunc, File: "api/api.go", Line: 5}}, report.Packages[0].Undocumented)
+	assert.Equal(t, "shop", report.Packages[1].Path)
+	require.Len(t, report.Packages[1].Undocumented, 1)
+	assert.Equal(t, "FreeShippingThreshold", report.Packages[1].Undocumented[0].Name)
+
+	report = Compute(symbols, docs, godoc)
+	assert.Equal(t, 3, report.Documented, "a doc comment counts when godoc segments are given")
+	assert.Empty(t, report.Packages[1].Undocumented)
+
+	assert.InDelta(t, 100.0, Compute(nil, docs, nil).Coverage, 0.001)
+}
@@ -0,0 +1,8 @@
+// Package coverage measures how many exported symbols the documentation
+// describes.
+//
+// A symbol counts as documented when a Markdown section mentions it by name
+// and, optionally, when it has a Go doc comment of its own. Symbols marked
+// with //docuguard:ignore are left out of the count. Results are grouped by
+// package directory, listing the undocumented symbols of each.
+package coverage
@@ -0,0 +1,9 @@
+// Package drift detects documentation that still quotes a literal value
+// removed by a code change.
+//
+// Numeric, string and time.Duration literals are extracted from the old and
+// new code of a changed symbol. Literals that disappeared in the change are
+// searched for in the matched documentation segment, yielding findings such as
+// "doc says 100, code now uses 500" with the exact documentation line. The
+// analysis is deterministic and needs no LLM.
+package drift
@@ -0,0 +1,213 @@
+package drift
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var (
+	// docNumberRegex matches numbers in prose, including thousands separators.
+	docNumberRegex = regexp.MustCompile(`\d[\d,]*(?:\.\d+)*`)
+	// docDurationRegex matches durations such as "30s", "30 sec" or "1.5 hours".
+	docDurationRegex = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(ns|nanoseconds?|µs|us|microseconds?|ms|milliseconds?|s|secs?|seconds?|m|mins?|minutes?|h|hrs?|hours?)\b`)
+)
+
+// docUnits maps duration units used in prose to their length.
+var docUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"µs": time.Microsecond, "us": time.Microsecond, "microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+}
+
+// minStringLen is the shortest string literal worth searching for;
+// shorter strings match too much prose by accident.
+const minStringLen = 3
+
+// Finding is a documentation line that still quotes a literal the change removed.
+type Finding struct {
+	// Old is the literal removed from the code.
+	Old Literal
+	// New is the literal that replaced it, or nil if none did.
+	New *Literal
+	// Line is the line number in the documentation file.
+	Line int
+	// Mention is the documentation text that quotes the old value.
+	Mention string
+}
+
+// Reason describes the finding, e.g. "doc says 100, code now uses 500".
+func (f Finding) Reason() string {
+	if f.New == nil {
+		return fmt.Sprintf("doc says %s (line %d), but the code no longer uses %s", f.Mention, f.Line, f.Old.Display())
+	}
+	return fmt.Sprintf("doc says %s (line %d), code now uses %s", f.Mention, f.Line, f.New.Display())
+}
+
+// Suggestion describes how to fix the documentation.
+func (f Finding) Suggestion() string {
+	if f.New == nil {
+		return fmt.Sprintf("Remove or revise the mention of %s on line %d", f.Mention, f.Line)
+	}
+	return fmt.Sprintf("Update %s to %s on line %d", f.Mention, f.New.Display(), f.Line)
+}
+
+// Detect reports the lines of the segment that mention a literal removed from
+// the symbol's code. Each removed literal is paired with the literal of the
+// same kind that took its place, in order of appearance.
+func Detect(segment types.DocSegment, symbol types.ChangedSymbol) []Finding {
+	removed, added := diffLiterals(ExtractLiterals(symbol.OldCode), ExtractLiterals(symbol.NewCode))
+	if len(removed) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(segment.Content, "\n")
+	addedByKind := make(map[Kind][]Literal)
+	for _, l := range added {
+		addedByKind[l.Kind] = append(addedByKind[l.Kind], l)
+	}
+
+	var findings []Finding
+	used := make(map[Kind]int)
+	for _, old := range removed {
+		var replacement *Literal
+		if candidates := addedByKind[old.Kind]; used[old.Kind] < len(candidates) {
+			replacement = &candidates[used[old.Kind]]
+		}
+		used[old.Kind]++
+
+		if skipLiteral(old) {
+			continue
+		}
+
+		for i, line := range lines {
+			mention, ok := findMention(line, old)
+			if !ok {
+				continue
+			}
+			// A line quoting both values already describes the change.
+			if replacement != nil {
+				if _, ok := findMention(line, *replacement); ok {
+					continue
+				}
+			}
+			findings = append(findings, Finding{
+				Old:     old,
+				New:     replacement,
+				Line:    segment.StartLine + i,
+				Mention: mention,
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Line < findings[j].Line })
+	return findings
+}
+
+// diffLiterals returns the literals only in old and only in new.
+func diffLiterals(old, new []Literal) (removed, added []Literal) {
+	key := func(l Literal) string { return string(l.Kind) + "\x00" + l.Value }
+
+	inNew := make(map[string]bool, len(new))
+	for _, l := range new {
+		inNew[key(l)] = true
+	}
+	inOld := make(map[string]bool, len(old))
+	for _, l := range old {
+		inOld[key(l)] = true
+		if !inNew[key(l)] {
+			removed = append(removed, l)
+		}
+	}
+	for _, l := range new {
+		if !inOld[key(l)] {
+			added = append(added, l)
+		}
+	}
+	return removed, added
+}
+
+// skipLiteral filters out literals too common to be meaningful in prose.
+func skipLiteral(l Literal) bool {
+	switch l.Kind {
+	case KindNumber:
+		return l.Value == "0" || l.Value == "1"
+	case KindString:
+		return utf8.RuneCountInString(strings.TrimSpace(l.Value)) < minStringLen
+	}
+	return false
+}
+
+// findMention returns the text in line that quotes the literal's value.
+func findMention(line string, l Literal) (string, bool) {
+	switch l.Kind {
+	case KindNumber:
+		for _, loc := range docNumberRegex.FindAllStringIndex(line, -1) {
+			text := line[loc[0]:loc[1]]
+			if strings.Count(text, ".") > 1 || partOfWord(line, loc[0], loc[1]) {
+				continue // version numbers and identifiers
+			}
+			if n, ok := parseNumber(text); ok && formatNumber(n) == l.Value {
+				return text, true
+			}
+		}
+
+	case KindDuration:
+		want, err := time.ParseDuration(l.Value)
+		if err != nil {
+			return "", false
+		}
+		for _, m := range docDurationRegex.FindAllStringSubmatch(line, -1) {
+			n, ok := parseNumber(m[1])
+			unit, known := docUnits[strings.ToLower(m[2])]
+			if ok && known && time.Duration(n*float64(unit)) == want {
+				return m[0], true
+			}
+		}
+
+	case KindString:
+		for start := 0; start < len(line); {
+			i := strings.Index(line[start:], l.Value)
+			if i < 0 {
+				break
+			}
+			i += start
+			end := i + len(l.Value)
+			if !partOfWord(line, i, end) {
+				return l.Value, true
+			}
+			start = i + 1
+		}
+	}
+	return "", false
+}
+
+// partOfWord reports whether line[start:end] is glued to a letter, digit or underscore.
+func partOfWord(line string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(line[:start])
+		if isWordRune(r) {
+			return true
+		}
+	}
+	if end < len(line) {
+		r, _ := utf8.DecodeRuneInString(line[end:])
+		if isWordRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
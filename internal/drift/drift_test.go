@@ -0,0 +1,82 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestExtractLiterals(t *testing.T) {
+	code := `func Connect() {
+	// Retries 3 times.
+	timeout := 30 * time.Second
+	backoff := time.Millisecond * 250
+	limit := 1_000
+	model := "gpt-4"
+	ratio := 0.75
+}`
+
+	literals := ExtractLiterals(code)
+	assert.Equal(t, []Literal{
+		{Kind: KindDuration, Text: "30 * time.Second", Value: "30s"},
+		{Kind: KindDuration, Text: "time.Millisecond * 250", Value: "250ms"},
+		{Kind: KindNumber, Text: "1_000", Value: "1000"},
+		{Kind: KindString, Text: `"gpt-4"`, Value: "gpt-4"},
+		{Kind: KindNumber, Text: "0.75", Value: "0.75"},
+	}, literals, "comments are ignored")
+}
+
+func TestDetect_Number(t *testing.T) {
+	segment := types.DocSegment{
+		File:      "docs/pricing.md",
+		StartLine: 10,
+		Content:   "## Free Shipping\n\nOrders over $100 ship free.\nOtherwise shipping costs $10.",
+	}
+	symbol := types.ChangedSymbol{
+		Name:    "CalculateShipping",
+		OldCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 100 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+		NewCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 500 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+	}
+
+	findings := Detect(segment, symbol)
+	require.Len(t, findings, 1)
+	f := findings[0]
+	assert.Equal(t, 12, f.Line)
+	assert.Equal(t, "100", f.Mention)
+	require.NotNil(t, f.New)
+	assert.Equal(t, "500", f.New.Text)
+	assert.Equal(t, "doc says 100 (line 12), code now uses 500", f.Reason())
+}
+
+func TestDetect_DurationAndString(t *testing.T) {
+	segment := types.DocSegment{
+		StartLine: 1,
+		Content:   "## Client\nRequests time out after 30 seconds.\nThe default model is gpt-4.\nSee gpt-4o for details.",
+	}
+	symbol := types.ChangedSymbol{
+		OldCode: "var defaultTimeout = 30 * time.Second\nconst defaultModel = \"gpt-4\"",
+		NewCode: "var defaultTimeout = time.Minute * 2\nconst defaultModel = \"gpt-4o\"",
+	}
+
+	findings := Detect(segment, symbol)
+	require.Len(t, findings, 2, "the line quoting gpt-4o already names the new value")
+
+	assert.Equal(t, 2, findings[0].Line)
+	assert.Equal(t, "30 seconds", findings[0].Mention)
+	assert.Equal(t, "2m0s", findings[0].New.Value)
+
+	assert.Equal(t, 3, findings[1].Line)
+	assert.Equal(t, "doc says gpt-4 (line 3), code now uses \"gpt-4o\"", findings[1].Reason())
+}
+
+func TestDetect_NoDrift(t *testing.T) {
+	segment := types.DocSegment{StartLine: 1, Content: "## Shipping\nOrders over $500 ship free. Version 1.100.2."}
+	symbol := types.ChangedSymbol{
+		OldCode: "if amount >= 100 { return 0 }",
+		NewCode: "if amount >= 500 { return 0 }",
+	}
+	assert.Empty(t, Detect(segment, symbol))
+}
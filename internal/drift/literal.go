@@ -0,0 +1,150 @@
+package drift
+
+import (
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the kind of a literal value.
+type Kind string
+
+const (
+	KindNumber   Kind = "number"
+	KindString   Kind = "string"
+	KindDuration Kind = "duration"
+)
+
+// Literal is a constant value written in Go code.
+type Literal struct {
+	Kind Kind
+	// Text is the literal as written in code, e.g. `30 * time.Second`.
+	Text string
+	// Value is the normalized value used for comparison, e.g. "30s".
+	Value string
+}
+
+// Display returns the literal the way documentation would quote it.
+func (l Literal) Display() string {
+	if l.Kind == KindNumber {
+		return l.Text
+	}
+	if l.Kind == KindString {
+		return strconv.Quote(l.Value)
+	}
+	return l.Value
+}
+
+// durationUnits maps time package unit constants to their duration.
+var durationUnits = map[string]time.Duration{
+	"Nanosecond":  time.Nanosecond,
+	"Microsecond": time.Microsecond,
+	"Millisecond": time.Millisecond,
+	"Second":      time.Second,
+	"Minute":      time.Minute,
+	"Hour":        time.Hour,
+}
+
+// lexToken is a scanned token with its literal text.
+type lexToken struct {
+	tok token.Token
+	lit string
+}
+
+// ExtractLiterals returns the distinct literals in a Go code snippet, in
+// order of appearance. Products of a number and a time unit such as
+// `30 * time.Second` are reported as a single duration.
+func ExtractLiterals(code string) []Literal {
+	if code == "" {
+		return nil
+	}
+
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	s.Init(file, []byte(code), nil, 0)
+
+	var toks []lexToken
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		toks = append(toks, lexToken{tok, lit})
+	}
+
+	seen := make(map[Literal]bool)
+	var literals []Literal
+	add := func(l Literal) {
+		key := Literal{Kind: l.Kind, Value: l.Value}
+		if !seen[key] {
+			seen[key] = true
+			literals = append(literals, l)
+		}
+	}
+
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		switch t.tok {
+		case token.INT, token.FLOAT:
+			n, ok := parseNumber(t.lit)
+			if !ok {
+				continue
+			}
+			// number * time.Unit
+			if unit, ok := unitAt(toks, i+2); ok && toks[i+1].tok == token.MUL {
+				add(durationLiteral(t.lit+" * time."+toks[i+4].lit, n, unit))
+				i += 4
+				continue
+			}
+			add(Literal{Kind: KindNumber, Text: t.lit, Value: formatNumber(n)})
+
+		case token.IDENT:
+			// time.Unit * number
+			if unit, ok := unitAt(toks, i); ok && i+4 < len(toks) && toks[i+3].tok == token.MUL {
+				if n, ok := parseNumber(toks[i+4].lit); ok && (toks[i+4].tok == token.INT || toks[i+4].tok == token.FLOAT) {
+					add(durationLiteral("time."+toks[i+2].lit+" * "+toks[i+4].lit, n, unit))
+					i += 4
+				}
+			}
+
+		case token.STRING:
+			value, err := strconv.Unquote(t.lit)
+			if err != nil {
+				continue
+			}
+			add(Literal{Kind: KindString, Text: t.lit, Value: value})
+		}
+	}
+
+	return literals
+}
+
+// unitAt reports whether toks[i:i+3] spells time.<Unit>.
+func unitAt(toks []lexToken, i int) (time.Duration, bool) {
+	if i+2 >= len(toks) || toks[i].tok != token.IDENT || toks[i].lit != "time" || toks[i+1].tok != token.PERIOD {
+		return 0, false
+	}
+	unit, ok := durationUnits[toks[i+2].lit]
+	return unit, ok
+}
+
+func durationLiteral(text string, n float64, unit time.Duration) Literal {
+	return Literal{Kind: KindDuration, Text: text, Value: time.Duration(n * float64(unit)).String()}
+}
+
+// parseNumber parses a Go or documentation number, ignoring digit separators.
+func parseNumber(s string) (float64, bool) {
+	s = strings.NewReplacer("_", "", ",", "").Replace(s)
+	if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return float64(i), true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,204 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blueberrycongee/docuguard/internal/cache"
+	"github.com/blueberrycongee/docuguard/internal/git"
+	"github.com/blueberrycongee/docuguard/internal/glob"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/internal/matcher"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// AuditOptions contains options for auditing the whole repository.
+type AuditOptions struct {
+	// CodePatterns are glob patterns for the Go files to audit.
+	CodePatterns []string
+	// DocPatterns are glob patterns for documentation files.
+	DocPatterns []string
+	// UseTwoStage pairs symbols with documentation by broad keyword matching
+	// and an LLM relevance filter instead of requiring the symbol's name.
+	UseTwoStage bool
+	// Journal, if set, records finished checks and answers checks recorded
+	// by an earlier, interrupted audit.
+	Journal *AuditJournal
+	// OnCheck, if set, is called after each pair is checked with the number
+	// of pairs done so far. Calls are serialized.
+	OnCheck func(done, total int)
+}
+
+// AuditSymbols returns the exported declarations of the Go files matched by
+// patterns. Tests, testdata, vendored code and directories starting with
+// "." or "_" are skipped, as the go tool does.
+func AuditSymbols(patterns []string, opts glob.Options) ([]types.ChangedSymbol, error) {
+	files, err := glob.Files(".", patterns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []types.ChangedSymbol
+	for _, file := range files {
+		file = filepath.ToSlash(file)
+		if !auditedSource(file) {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileSymbols, err := git.DeclaredSymbols(file, src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		symbols = append(symbols, fileSymbols...)
+	}
+	return symbols, nil
+}
+
+// auditedSource reports whether a slash-separated path is a non-test Go
+// source file outside directories the go tool ignores.
+func auditedSource(file string) bool {
+	if path.Ext(file) != ".go" || strings.HasSuffix(file, "_test.go") {
+		return false
+	}
+	dirs := strings.Split(path.Dir(file), "/")
+	for _, dir := range dirs {
+		if dir == "testdata" || dir == "vendor" ||
+			(dir != "." && dir != ".." && (strings.HasPrefix(dir, ".") || strings.HasPrefix(dir, "_"))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Audit checks every exported declaration against the documentation that
+// mentions it, regardless of whether any diff touched it. It finds drift
+// that predates DocuGuard or that no one bound with annotations.
+// Each pair is checked as the code is now, without a before/after change.
+func (e *PREngine) Audit(ctx context.Context, opts AuditOptions) (*types.PRReport, error) {
+	startTime := time.Now()
+	startUsage := e.meter.Usage()
+	report := &types.PRReport{}
+	scanOpts := glob.Options{
+		Exclude:   e.cfg.Scan.Exclude,
+		GitIgnore: e.cfg.Scan.GitIgnore,
+	}
+
+	symbols, err := AuditSymbols(opts.CodePatterns, scanOpts)
+	if err != nil {
+		return nil, err
+	}
+	report.TotalSymbols = len(symbols)
+
+	if len(symbols) == 0 {
+		report.ExecutionTimeMs = time.Since(startTime).Milliseconds()
+		return report, nil
+	}
+
+	segments, err := scanner.ScanMarkdownDir(".", opts.DocPatterns, scanOpts)
+	if err != nil {
+		return nil, err
+	}
+	report.TotalSegments = len(segments)
+
+	var pairs []types.RelevanceResult
+	if opts.UseTwoStage {
+		var failed []error
+		if pairs, failed, err = e.twoStageMatch(ctx, symbols, segments, true); err != nil {
+			return nil, err
+		}
+		report.Skipped = budgetSkipped(failed)
+		report.Suppressed = matcher.Suppressed(symbols, segments)
+	} else {
+		pairs = matcher.NameMatch(symbols, segments)
+		report.Suppressed = matcher.NameSuppressed(symbols, segments)
+	}
+	report.RelevantPairs = len(pairs)
+
+	var mu sync.Mutex
+	done := 0
+	report.Results = make([]types.PRCheckResult, len(pairs))
+	err = forEach(ctx, len(pairs), e.cfg.LLM.MaxConcurrency, func(i int) {
+		report.Results[i] = e.auditConsistency(ctx, pairs[i].Segment, pairs[i].Symbol, opts.Journal)
+		if opts.OnCheck != nil {
+			mu.Lock()
+			done++
+			opts.OnCheck(done, len(pairs))
+			mu.Unlock()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summarizePR(e.cfg.Rules, e.baseline, report)
+
+	report.Usage = usageSince(e.meter, startUsage)
+	report.ExecutionTimeMs = time.Since(startTime).Milliseconds()
+	return report, nil
+}
+
+// auditConsistency checks whether a document segment describes the current
+// code of a symbol correctly, reusing a verdict from the journal if present.
+func (e *PREngine) auditConsistency(
+	ctx context.Context,
+	segment types.DocSegment,
+	symbol types.ChangedSymbol,
+	journal *AuditJournal,
+) types.PRCheckResult {
+	result := types.PRCheckResult{
+		Segment: segment,
+		Symbol:  symbol,
+		Status:  types.StatusChecked,
+	}
+
+	key := cache.Key(llm.PromptVersion, e.cfg.LLM.Provider, e.cfg.LLM.Model,
+		segment.File, segment.Heading, segment.Content, symbol.File, symbol.Name, symbol.NewCode)
+	if journal.lookup(key, &result) {
+		return result
+	}
+
+	budget := newBudget(e.cfg)
+	code := fitCode(budget, symbol.NewCode)[0]
+	chunks := llm.ChunkText(segment.Content, docBudget(budget, code), llm.ChunkOverlapLines)
+
+	verdicts := make([]*types.CheckResult, len(chunks))
+	for i, chunk := range chunks {
+		req := llm.AnalyzeRequest{
+			DocContent:  chunk.Text,
+			CodeContent: code,
+			CodeSymbol:  symbol.Name,
+			CodeFile:    symbol.File,
+		}
+
+		var err error
+		if verdicts[i], err = e.llmClient.Analyze(ctx, req); err != nil {
+			// Failed and skipped checks are not recorded, so a resumed
+			// audit retries them.
+			if errors.Is(err, llm.ErrBudgetExceeded) {
+				return skippedResult(result)
+			}
+			return failedResult(result, err)
+		}
+	}
+	llmResult := mergeChunks(verdicts)
+
+	result.Related = llmResult.Related
+	result.Consistent = llmResult.Consistent
+	result.Confidence = llmResult.Confidence
+	result.Reason = llmResult.Reason
+	result.Suggestion = llmResult.Suggestion
+
+	journal.record(key, result)
+	return result
+}
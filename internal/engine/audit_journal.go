@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// DefaultAuditJournal is the audit progress file relative to the project root.
+const DefaultAuditJournal = ".docuguard/audit-progress.jsonl"
+
+// AuditJournal records the verdict of every finished audit check in a JSON
+// Lines file, so that an audit interrupted by a crash, a rate limit or
+// Ctrl-C resumes where it stopped instead of paying for the same checks again.
+// Entries are keyed by the prompt version, model and the exact documentation
+// and code checked, so edits in between invalidate them.
+type AuditJournal struct {
+	path string
+
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]auditEntry
+	resumed int
+	err     error
+}
+
+// auditEntry is one line of the journal.
+type auditEntry struct {
+	Key        string  `json:"key"`
+	Related    bool    `json:"related"`
+	Consistent bool    `json:"consistent"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+	Suggestion string  `json:"suggestion,omitempty"`
+}
+
+// OpenAuditJournal opens the journal at path, loading the entries of an
+// earlier audit unless fresh is set, in which case they are discarded.
+func OpenAuditJournal(path string, fresh bool) (*AuditJournal, error) {
+	if path == "" {
+		path = DefaultAuditJournal
+	}
+	j := &AuditJournal{path: path, entries: make(map[string]auditEntry)}
+
+	if !fresh {
+		if err := j.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if fresh {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	j.file = file
+	return j, nil
+}
+
+// load reads existing entries. Lines that do not decode, such as one cut
+// short when the previous run was killed mid-write, are ignored.
+func (j *AuditJournal) load() error {
+	file, err := os.Open(j.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e auditEntry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil && e.Key != "" {
+			j.entries[e.Key] = e
+		}
+	}
+	return scanner.Err()
+}
+
+// Path returns the location of the journal file.
+func (j *AuditJournal) Path() string {
+	return j.path
+}
+
+// Len returns the number of checks recorded by earlier audits.
+func (j *AuditJournal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.entries)
+}
+
+// Resumed returns how many checks were answered from the journal.
+func (j *AuditJournal) Resumed() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.resumed
+}
+
+// Err returns the first error from writing the journal, if any. Checks
+// after a failed write still run but will not be resumable.
+func (j *AuditJournal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// lookup fills result from a recorded verdict. A nil journal never matches.
+func (j *AuditJournal) lookup(key string, result *types.PRCheckResult) bool {
+	if j == nil {
+		return false
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	e, ok := j.entries[key]
+	if !ok {
+		return false
+	}
+	j.resumed++
+	result.Related = e.Related
+	result.Consistent = e.Consistent
+	result.Confidence = e.Confidence
+	result.Reason = e.Reason
+	result.Suggestion = e.Suggestion
+	return true
+}
+
+// record appends the verdict of a finished check. A nil journal records nothing.
+func (j *AuditJournal) record(key string, result types.PRCheckResult) {
+	if j == nil {
+		return
+	}
+	e := auditEntry{
+		Key:        key,
+		Related:    result.Related,
+		Consistent: result.Consistent,
+		Confidence: result.Confidence,
+		Reason:     result.Reason,
+		Suggestion: result.Suggestion,
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries[key] = e
+	if j.err != nil {
+		return
+	}
+	// One write per line keeps entries whole even if the process is killed.
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		j.err = err
+	}
+}
+
+// Close closes the journal file, keeping it for a later resume.
+func (j *AuditJournal) Close() error {
+	return j.file.Close()
+}
+
+// Remove closes and deletes the journal once an audit has completed.
+func (j *AuditJournal) Remove() error {
+	j.file.Close()
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
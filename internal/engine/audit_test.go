@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/glob"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestPREngineAudit(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"shop/shop.go": `package shop
+
+// CalculateShipping returns the shipping fee.
+func CalculateShipping(total float64) float64 {
+	if total >= 500 {
+		return 0
+	}
+	return 10
+}
+
+func helper() {}
+
+type cart struct{}
+
+func (c *cart) Total() float64 { return 0 }
+`,
+		"shop/shop_test.go":        "package shop\n\nfunc TestHelper() {}\n",
+		"shop/testdata/fixture.go": "package fixture\n\nfunc Fixture() {}\n",
+		"README.md":                "# Shop\n\n## Shipping\n\nCalculateShipping is free over $100. The helper and Total are internal.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	symbols, err := AuditSymbols([]string{"**/*.go"}, glob.Options{})
+	require.NoError(t, err)
+	require.Len(t, symbols, 1, "unexported, test and testdata declarations are skipped")
+	assert.Equal(t, "CalculateShipping", symbols[0].Name)
+	assert.Equal(t, types.ChangeNone, symbols[0].ChangeType)
+
+	opts := AuditOptions{CodePatterns: []string{"**/*.go"}, DocPatterns: []string{"*.md"}}
+	cfg := &config.Config{Rules: config.RuleConfig{ConfidenceThreshold: 0.8}}
+
+	journal, err := OpenAuditJournal(filepath.Join(dir, "progress.jsonl"), false)
+	require.NoError(t, err)
+	opts.Journal = journal
+	e := &PREngine{cfg: cfg, llmClient: &llm.MockClient{Result: &types.CheckResult{
+		Related: true, Consistent: false, Confidence: 0.9, Reason: "threshold is 500",
+	}}}
+	var calls int
+	opts.OnCheck = func(done, total int) { calls++ }
+
+	report, err := e.Audit(context.Background(), opts)
+	require.NoError(t, err)
+	require.NoError(t, journal.Close())
+	assert.Equal(t, 1, report.TotalSymbols)
+	assert.Equal(t, 1, report.RelevantPairs)
+	assert.Equal(t, 1, report.Inconsistent)
+	assert.Equal(t, 1, calls)
+
+	// A resumed audit answers recorded checks without the LLM.
+	journal, err = OpenAuditJournal(filepath.Join(dir, "progress.jsonl"), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, journal.Len())
+	opts.Journal = journal
+	e.llmClient = &llm.MockClient{Err: errors.New("rate limited")}
+
+	report, err = e.Audit(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, journal.Resumed())
+	assert.Equal(t, 0, report.Errors)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "threshold is 500", report.Results[0].Reason)
+	require.NoError(t, journal.Remove())
+	assert.NoFileExists(t, filepath.Join(dir, "progress.jsonl"))
+}
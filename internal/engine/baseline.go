@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"github.com/blueberrycongee/docuguard/internal/baseline"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// loadBaseline reads the rules.baseline file. An empty path disables the
+// baseline and a missing file is treated as an empty one.
+func loadBaseline(cfg *config.Config) (*baseline.Baseline, error) {
+	if cfg.Rules.Baseline == "" {
+		return nil, nil
+	}
+	return baseline.Load(cfg.Rules.Baseline)
+}
+
+// markBaselined flags check-mode inconsistencies recorded in the baseline
+// and returns the entries for this run's bindings that no longer reproduce.
+func markBaselined(b *baseline.Baseline, results []types.CheckResult) []types.BaselineEntry {
+	var findings, checked []baseline.Finding
+	var index []int
+	for i, r := range results {
+		f := baseline.Finding{DocFile: r.DocLoc.File, Heading: r.Heading, Symbol: r.CodeLoc.Symbol, Reason: r.Reason}
+		checked = append(checked, f)
+		if !r.Consistent {
+			findings = append(findings, f)
+			index = append(index, i)
+		}
+	}
+
+	baselined, stale := b.Match(findings, checked)
+	for k, ok := range baselined {
+		results[index[k]].Baselined = ok
+	}
+	return stale
+}
+
+// markBaselinedPR flags PR-mode inconsistencies recorded in the baseline and
+// returns the entries for this run's pairs that no longer reproduce.
+// Failed and skipped checks neither match nor clear an entry.
+func markBaselinedPR(b *baseline.Baseline, results []types.PRCheckResult) []types.BaselineEntry {
+	var findings, checked []baseline.Finding
+	var index []int
+	for i, r := range results {
+		if r.Status == types.StatusError || r.Status == types.StatusSkipped {
+			continue
+		}
+		f := baseline.Finding{DocFile: r.Segment.File, Heading: r.Segment.Heading, Symbol: r.Symbol.Name, Reason: r.Reason}
+		checked = append(checked, f)
+		if !r.Consistent {
+			findings = append(findings, f)
+			index = append(index, i)
+		}
+	}
+
+	baselined, stale := b.Match(findings, checked)
+	for k, ok := range baselined {
+		results[index[k]].Baselined = ok
+	}
+	return stale
+}
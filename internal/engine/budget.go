@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// candidateOverhead approximates the tokens a relevance candidate takes
+// besides its heading and content: its index, separators and blank lines.
+const candidateOverhead = 8
+
+// newBudget returns the prompt budget of the configured model.
+func newBudget(cfg *config.Config) llm.Budget {
+	return llm.NewBudget(cfg.LLM.Model, cfg.LLM.ContextTokens)
+}
+
+// fitCode truncates code so that all of it takes at most half of the input
+// budget, leaving the other half to documentation.
+func fitCode(b llm.Budget, code ...string) []string {
+	if len(code) == 0 {
+		return nil
+	}
+	share := b.Input() / 2 / len(code)
+	fitted := make([]string, len(code))
+	for i, c := range code {
+		fitted[i] = llm.Truncate(c, share)
+	}
+	return fitted
+}
+
+// docBudget returns the tokens left for documentation next to code.
+func docBudget(b llm.Budget, code ...string) int {
+	left := b.Input()
+	for _, c := range code {
+		left -= llm.EstimateTokens(c)
+	}
+	return left
+}
+
+// checkRewriteSize refuses documentation too large to be rewritten in one
+// request. The rewrite is as long as the documentation, so the
+// documentation may take only half of what the code leaves.
+func checkRewriteSize(b llm.Budget, doc string, code ...string) error {
+	limit := docBudget(b, code...) / 2
+	if tokens := llm.EstimateTokens(doc); tokens > limit {
+		return fmt.Errorf("documentation is too large to rewrite in one request (about %d tokens, limit %d)", tokens, limit)
+	}
+	return nil
+}
+
+// mergeChunks combines the verdicts on the chunks of one documentation
+// text. The text is inconsistent if any chunk is, and the most confident
+// finding stands for it; otherwise the most confident related verdict does.
+// The reason names the chunk the verdict came from.
+func mergeChunks(verdicts []*types.CheckResult) *types.CheckResult {
+	if len(verdicts) == 1 {
+		return verdicts[0]
+	}
+	best := 0
+	rank := func(r *types.CheckResult) int {
+		switch {
+		case !r.Consistent:
+			return 2
+		case r.Related:
+			return 1
+		}
+		return 0
+	}
+	for i, r := range verdicts {
+		b := verdicts[best]
+		if rank(r) > rank(b) || rank(r) == rank(b) && r.Confidence > b.Confidence {
+			best = i
+		}
+	}
+	merged := *verdicts[best]
+	merged.Reason = fmt.Sprintf("%s (part %d of %d)", merged.Reason, best+1, len(verdicts))
+	return &merged
+}
+
+// relevanceBatches splits the candidates of a relevance request into runs
+// [start, end) whose prompt fits the budget next to the symbol's code.
+// Every batch holds at least one candidate.
+func relevanceBatches(b llm.Budget, candidates []types.DocSegment, code ...string) [][2]int {
+	limit := docBudget(b, code...)
+	var batches [][2]int
+	start, used := 0, 0
+	for i, seg := range candidates {
+		cost := candidateOverhead + llm.EstimateTokens(seg.File+" - "+seg.Heading) +
+			min(llm.EstimateTokens(seg.Content), llm.CandidateTokens)
+		if i > start && used+cost > limit {
+			batches = append(batches, [2]int{start, i})
+			start, used = i, 0
+		}
+		used += cost
+	}
+	if start < len(candidates) {
+		batches = append(batches, [2]int{start, len(candidates)})
+	}
+	return batches
+}
+
+// usageSince returns the LLM usage the meter recorded after start, or nil
+// when no request reached a provider.
+func usageSince(m *llm.Meter, start types.Usage) *types.Usage {
+	end := m.Usage()
+	sub := func(a, b types.TokenUsage) types.TokenUsage {
+		return types.TokenUsage{
+			Calls:            a.Calls - b.Calls,
+			PromptTokens:     a.PromptTokens - b.PromptTokens,
+			CompletionTokens: a.CompletionTokens - b.CompletionTokens,
+			Cost:             a.Cost - b.Cost,
+		}
+	}
+	usage := types.Usage{
+		Relevance:   sub(end.Relevance, start.Relevance),
+		Consistency: sub(end.Consistency, start.Consistency),
+		Rewrite:     sub(end.Rewrite, start.Rewrite),
+	}
+	if usage.Total().Calls == 0 {
+		return nil
+	}
+	return &usage
+}
@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// budgetClient records the requests it gets. AnalyzePR finds the
+// documentation inconsistent where it mentions "$50".
+type budgetClient struct {
+	llm.MockClient
+	mu         sync.Mutex
+	relevance  []llm.RelevanceRequest
+	prSegments []types.DocSegment
+}
+
+func (c *budgetClient) CheckRelevanceBatch(ctx context.Context, req llm.RelevanceRequest) ([]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.relevance = append(c.relevance, req)
+	return []int{0}, nil
+}
+
+func (c *budgetClient) AnalyzePR(ctx context.Context, req llm.PRAnalyzeRequest) (*types.CheckResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prSegments = append(c.prSegments, req.Segment)
+	if strings.Contains(req.Segment.Content, "$50") {
+		return &types.CheckResult{Related: true, Consistent: false, Confidence: 0.9, Reason: "threshold is $100"}, nil
+	}
+	return &types.CheckResult{Related: true, Consistent: true, Confidence: 0.8, Reason: "matches"}, nil
+}
+
+func TestTwoStageMatch_BatchesByTokenBudget(t *testing.T) {
+	symbol := types.ChangedSymbol{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop.go",
+		NewCode: "func CalculateShipping(total float64) float64 { return 0 }"}
+	var segments []types.DocSegment
+	for i := 0; i < 12; i++ {
+		segments = append(segments, types.DocSegment{File: "docs/shop.md", StartLine: i * 10, Heading: fmt.Sprintf("Section %d", i),
+			Content: "CalculateShipping " + strings.Repeat("charges shipping below the threshold. ", 30)})
+	}
+
+	client := &budgetClient{}
+	cfg := &config.Config{LLM: config.LLMConfig{ContextTokens: 1200}}
+	e := &PREngine{cfg: cfg, llmClient: client}
+
+	pairs, _, err := e.twoStageMatch(context.Background(), []types.ChangedSymbol{symbol}, segments, true)
+	require.NoError(t, err)
+
+	require.Greater(t, len(client.relevance), 1, "candidates are split across requests")
+	total, limit := 0, newBudget(cfg).Input()
+	var want []string
+	for _, req := range client.relevance {
+		used := llm.EstimateTokens(req.Symbol.NewCode)
+		for _, seg := range req.Candidates {
+			used += llm.EstimateTokens(llm.Truncate(seg.Content, llm.CandidateTokens))
+		}
+		assert.LessOrEqual(t, used, limit)
+		want = append(want, segments[total].Heading)
+		total += len(req.Candidates)
+	}
+	assert.Equal(t, len(segments), total)
+
+	// Indices of each batch map back to the candidates it held.
+	var got []string
+	for _, p := range pairs {
+		got = append(got, p.Segment.Heading)
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCheckConsistency_ChunksLargeSections(t *testing.T) {
+	var lines []string
+	for i := 0; i < 60; i++ {
+		lines = append(lines, fmt.Sprintf("Line %d describes how CalculateShipping handles orders.", i))
+	}
+	lines[45] = "Orders over $50 ship free."
+	segment := types.DocSegment{File: "docs/shop.md", StartLine: 1, EndLine: 61, Heading: "Shipping",
+		Content: "## Shipping\n" + strings.Join(lines, "\n")}
+	symbol := types.ChangedSymbol{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop.go",
+		NewCode: "func CalculateShipping(total float64) float64 { return 0 }"}
+
+	client := &budgetClient{}
+	e := &PREngine{cfg: &config.Config{LLM: config.LLMConfig{ContextTokens: 600}}, llmClient: client}
+
+	result := e.checkConsistency(context.Background(), segment, symbol, false)
+
+	require.Greater(t, len(client.prSegments), 1)
+	assert.Equal(t, segment, result.Segment, "the result covers the whole section")
+	assert.False(t, result.Consistent)
+	assert.Equal(t, 0.9, result.Confidence)
+	assert.Contains(t, result.Reason, "threshold is $100 (part ")
+	for _, seg := range client.prSegments {
+		assert.Equal(t, "Shipping", seg.Heading)
+		assert.Equal(t, seg.StartLine+strings.Count(seg.Content, "\n"), seg.EndLine)
+	}
+}
+
+func TestCheckConsistency_SkipsOverBudget(t *testing.T) {
+	segment := types.DocSegment{File: "docs/shop.md", StartLine: 1, EndLine: 3, Heading: "Shipping",
+		Content: "## Shipping\n\nOrders over $100 ship free."}
+	symbol := types.ChangedSymbol{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop.go"}
+
+	e := &PREngine{cfg: &config.Config{}, llmClient: &llm.MockClient{Err: fmt.Errorf("analyze: %w", llm.ErrBudgetExceeded)}}
+	report := &types.PRReport{Results: []types.PRCheckResult{
+		e.checkConsistency(context.Background(), segment, symbol, false),
+	}}
+	summarizePR(e.cfg.Rules, nil, report)
+
+	r := report.Results[0]
+	assert.Equal(t, types.StatusSkipped, r.Status)
+	assert.True(t, r.Consistent, "a skipped pair is not a finding")
+	assert.Equal(t, 1, report.Skipped)
+	assert.Zero(t, report.Errors)
+	assert.Zero(t, report.Inconsistent)
+}
+
+func TestTwoStageMatch_BudgetExceededSkipsCandidates(t *testing.T) {
+	symbol := types.ChangedSymbol{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop.go"}
+	segments := []types.DocSegment{
+		{File: "docs/shop.md", StartLine: 1, Heading: "Shipping", Content: "CalculateShipping charges $10."},
+		{File: "docs/faq.md", StartLine: 1, Heading: "Fees", Content: "See CalculateShipping."},
+	}
+
+	e := &PREngine{cfg: &config.Config{}, llmClient: &llm.MockClient{Err: llm.ErrBudgetExceeded}}
+	pairs, failed, err := e.twoStageMatch(context.Background(), []types.ChangedSymbol{symbol}, segments, true)
+	require.NoError(t, err)
+
+	assert.Empty(t, pairs, "unfiltered candidates are not kept when the budget ran out")
+	assert.Equal(t, 2, budgetSkipped(failed))
+
+	// Other failures keep the candidates for PR checking.
+	e.llmClient = &llm.MockClient{Err: errors.New("connection refused")}
+	pairs, failed, err = e.twoStageMatch(context.Background(), []types.ChangedSymbol{symbol}, segments, true)
+	require.NoError(t, err)
+	assert.Len(t, pairs, 2)
+	assert.Empty(t, failed)
+}
+
+func TestNewLLMClient_MaxCostNeedsPrice(t *testing.T) {
+	cfg := &config.Config{LLM: config.LLMConfig{Provider: "openai", Model: "gpt-4o", Budget: config.BudgetConfig{MaxCost: 1}}}
+	_, _, err := newLLMClient(cfg)
+	assert.ErrorContains(t, err, `needs a price for model "gpt-4o"`)
+
+	cfg.LLM.Prices = map[string]config.PriceConfig{"gpt-4": {Prompt: 2.5, Completion: 10}}
+	_, meter, err := newLLMClient(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, meter)
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/cache"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+)
+
+// newLLMClient creates the provider client described by cfg and wraps it
+// with the configured retry policy and, if enabled, the response cache and
+// transcript recording. The replay provider serves the transcript instead.
+// The returned meter counts the tokens of every call and enforces the
+// configured budget. A cost budget needs a price for the model.
+func newLLMClient(cfg *config.Config) (llm.Client, *llm.Meter, error) {
+	price, priced := modelPrice(cfg)
+	meter := llm.NewMeter(price, llm.Limits{
+		MaxTokens: cfg.LLM.Budget.MaxTokens,
+		MaxCost:   cfg.LLM.Budget.MaxCost,
+	})
+
+	// Replayed responses need no retries, caching or recording.
+	if cfg.LLM.Provider == "replay" {
+		client, err := llm.NewReplayClient(cfg.LLM.Transcript)
+		if err != nil {
+			return nil, nil, err
+		}
+		return llm.NewMeteredClient(client, meter), meter, nil
+	}
+
+	// Without a price every call costs nothing and the budget never trips.
+	if cfg.LLM.Budget.MaxCost > 0 && !priced {
+		return nil, nil, fmt.Errorf("llm.budget.max_cost needs a price for model %q in llm.prices", cfg.LLM.Model)
+	}
+
+	client, err := llm.NewClient(
+		cfg.LLM.Provider,
+		cfg.LLM.Model,
+		cfg.LLM.APIKey,
+		cfg.LLM.BaseURL,
+		cfg.LLM.Timeout,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create LLM client: %w", err)
+	}
+
+	var wrapped llm.Client = llm.NewRetryClient(client, llm.RetryPolicy{
+		MaxAttempts:    cfg.LLM.Retry.MaxAttempts,
+		InitialBackoff: cfg.LLM.Retry.InitialBackoff,
+		MaxBackoff:     cfg.LLM.Retry.MaxBackoff,
+	})
+
+	if cfg.Cache.Enabled {
+		wrapped = llm.NewCachedClient(wrapped, cache.NewStore(cfg.Cache.Dir), cfg.LLM.Model)
+	}
+
+	// Recording sits outside the cache so cached verdicts are recorded too.
+	if cfg.LLM.Record {
+		if wrapped, err = llm.NewRecordingClient(wrapped, cfg.LLM.Transcript); err != nil {
+			return nil, nil, fmt.Errorf("failed to open transcript: %w", err)
+		}
+	}
+
+	// Metering is outermost so a call over budget reaches nothing.
+	return llm.NewMeteredClient(wrapped, meter), meter, nil
+}
+
+// modelPrice returns the configured price of the model, matching the
+// longest model name prefix of llm.prices, and whether one matched.
+// Unpriced models cost nothing.
+func modelPrice(cfg *config.Config) (llm.Price, bool) {
+	var price llm.Price
+	matched := -1
+	for prefix, p := range cfg.LLM.Prices {
+		if strings.HasPrefix(strings.ToLower(cfg.LLM.Model), strings.ToLower(prefix)) && len(prefix) > matched {
+			price, matched = llm.Price{Prompt: p.Prompt, Completion: p.Completion}, len(prefix)
+		}
+	}
+	return price, matched >= 0
+}
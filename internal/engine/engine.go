@@ -2,10 +2,13 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/blueberrycongee/docuguard/internal/baseline"
 	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/graph"
 	"github.com/blueberrycongee/docuguard/internal/llm"
 	"github.com/blueberrycongee/docuguard/internal/parser"
 	"github.com/blueberrycongee/docuguard/pkg/types"
@@ -16,32 +19,50 @@ type Engine struct {
 	cfg       *config.Config
 	llmClient llm.Client
 	goParser  *parser.GoParser
+	baseline  *baseline.Baseline
+	// meter counts the LLM usage of the engine's calls.
+	meter *llm.Meter
+
+	// codeBindings holds the bindings declared in Go by //docuguard:doc.
+	codeBindings *graph.Graph
+	unresolved   []error
 }
 
 // New creates a new Engine instance.
 func New(cfg *config.Config) (*Engine, error) {
-	client, err := llm.NewClient(
-		cfg.LLM.Provider,
-		cfg.LLM.Model,
-		cfg.LLM.APIKey,
-		cfg.LLM.BaseURL,
-	)
+	client, meter, err := newLLMClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := loadBaseline(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create LLM client: %w", err)
+		return nil, err
+	}
+
+	goParser := parser.NewGoParser()
+	codeBindings, unresolved, err := loadCodeBindings(cfg, goParser)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Engine{
-		cfg:       cfg,
-		llmClient: client,
-		goParser:  parser.NewGoParser(),
+		cfg:          cfg,
+		llmClient:    client,
+		goParser:     goParser,
+		baseline:     b,
+		meter:        meter,
+		codeBindings: codeBindings,
+		unresolved:   unresolved,
 	}, nil
 }
 
 // CheckFile checks a single documentation file for consistency.
 func (e *Engine) CheckFile(ctx context.Context, docPath string) (*types.Report, error) {
 	startTime := time.Now()
+	startUsage := e.meter.Usage()
 
-	bindings, err := parser.ExtractBindings(docPath)
+	bindings, err := e.bindings(docPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract bindings: %w", err)
 	}
@@ -51,57 +72,209 @@ func (e *Engine) CheckFile(ctx context.Context, docPath string) (*types.Report,
 		Results:       make([]types.CheckResult, 0, len(bindings)),
 	}
 
-	for _, binding := range bindings {
-		result, err := e.checkBinding(ctx, binding)
-		if err != nil {
-			report.Errors++
-			continue
+	bindings, report.Suppressed = e.splitSuppressed(bindings)
+
+	// Bindings of one block are checked together in a single LLM request.
+	groups := groupBlocks(bindings)
+	blockResults := make([][]*types.CheckResult, len(groups))
+	blockErrs := make([][]error, len(groups))
+	err = forEach(ctx, len(groups), e.cfg.LLM.MaxConcurrency, func(i int) {
+		blockResults[i], blockErrs[i] = e.checkBlock(ctx, groups[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, results := range blockResults {
+		for j, result := range results {
+			err := blockErrs[i][j]
+			switch {
+			case result == nil && errors.Is(err, llm.ErrBudgetExceeded):
+				report.Skipped++
+				continue
+			case result == nil:
+				b := groups[i][j]
+				report.Errors++
+				report.Failures = append(report.Failures, fmt.Sprintf("%s:%d: %s in %s: %v",
+					b.DocFile, b.DocLine, b.CodeSymbol, b.CodeFile, err))
+				continue
+			}
+			report.Results = append(report.Results, *result)
 		}
+	}
+	report.StaleBaseline = markBaselined(e.baseline, report.Results)
 
-		if result.Consistent {
+	for i := range report.Results {
+		result := &report.Results[i]
+		result.Severity = classify(e.cfg.Rules, result.Consistent, result.Confidence)
+		switch {
+		case result.Consistent:
 			report.Consistent++
-		} else {
+		case result.Baselined:
+			report.Baselined++
+		default:
 			report.Inconsistent++
+			if blocks(e.cfg.Rules, result.Severity) {
+				report.Blocking++
+			}
 		}
-		report.Results = append(report.Results, *result)
 	}
 
+	report.Usage = usageSince(e.meter, startUsage)
 	report.ExecutionTimeMs = time.Since(startTime).Milliseconds()
 	return report, nil
 }
 
-func (e *Engine) checkBinding(ctx context.Context, binding types.Binding) (*types.CheckResult, error) {
-	codeContent, codeLine, err := e.goParser.ExtractSymbol(
-		binding.CodeFile,
-		binding.CodeSymbol,
-		binding.CodeType,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract code: %w", err)
+// splitSuppressed separates bindings excluded by a docuguard:ignore
+// directive, in the doc block or on the bound Go declaration.
+func (e *Engine) splitSuppressed(bindings []types.Binding) ([]types.Binding, []types.SuppressedPair) {
+	var checked []types.Binding
+	var suppressed []types.SuppressedPair
+	for _, b := range bindings {
+		s := parser.FindSuppression(b.Suppressions, b.CodeSymbol)
+		if s == nil {
+			// An unreadable code file is reported by checkBlock.
+			s, _ = e.goParser.FindIgnore(b.CodeFile, b.CodeSymbol, b.CodeType)
+		}
+		if s == nil {
+			checked = append(checked, b)
+			continue
+		}
+		suppressed = append(suppressed, types.SuppressedPair{
+			DocFile:     b.DocFile,
+			DocLine:     b.DocLine,
+			CodeFile:    b.CodeFile,
+			Symbol:      b.CodeSymbol,
+			Suppression: *s,
+		})
 	}
+	return checked, suppressed
+}
 
-	if codeContent == "" {
-		return &types.CheckResult{
-			Consistent: false,
-			Confidence: 1.0,
-			Reason:     fmt.Sprintf("symbol %s not found in file %s", binding.CodeSymbol, binding.CodeFile),
-			DocLoc:     types.Location{File: binding.DocFile, Line: binding.DocLine},
-			CodeLoc:    types.Location{File: binding.CodeFile, Line: 0},
-		}, nil
+// groupBlocks groups bindings by the block they are declared in, keeping
+// the order in which blocks and bindings appear.
+func groupBlocks(bindings []types.Binding) [][]types.Binding {
+	var blocks [][]types.Binding
+	index := make(map[string]int)
+	for _, b := range bindings {
+		key := fmt.Sprintf("%s:%d", b.DocFile, b.BlockLine)
+		i, ok := index[key]
+		if !ok {
+			i = len(blocks)
+			index[key] = i
+			blocks = append(blocks, nil)
+		}
+		blocks[i] = append(blocks[i], b)
 	}
+	return blocks
+}
 
-	result, err := e.llmClient.Analyze(ctx, llm.AnalyzeRequest{
-		DocContent:  binding.DocContent,
-		CodeContent: codeContent,
-		CodeSymbol:  binding.CodeSymbol,
-		CodeFile:    binding.CodeFile,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("LLM analysis failed: %w", err)
+// checkBlock checks the bindings of one block and returns a result per
+// binding, or, where the check failed, the error of that binding. A block
+// bound to several symbols is
+// analyzed in one request so the model sees all the code the documentation
+// describes, and each finding is attributed to the symbol it concerns.
+// Documentation too large for one request is checked in overlapping chunks.
+func (e *Engine) checkBlock(ctx context.Context, block []types.Binding) ([]*types.CheckResult, []error) {
+	results := make([]*types.CheckResult, len(block))
+	errs := make([]error, len(block))
+	// fail records err for every binding whose code was found.
+	fail := func(found []int, err error) ([]*types.CheckResult, []error) {
+		for _, i := range found {
+			errs[i] = err
+		}
+		return results, errs
 	}
 
-	result.DocLoc = types.Location{File: binding.DocFile, Line: binding.DocLine}
-	result.CodeLoc = types.Location{File: binding.CodeFile, Line: codeLine, Symbol: binding.CodeSymbol}
+	var found []int
+	var symbols []llm.BlockSymbol
+	codeLines := make([]int, len(block))
+	for i, binding := range block {
+		codeContent, codeLine, err := e.goParser.ExtractSymbol(
+			binding.CodeFile,
+			binding.CodeSymbol,
+			binding.CodeType,
+		)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if codeContent == "" {
+			results[i] = &types.CheckResult{
+				Consistent: false,
+				Confidence: 1.0,
+				Reason:     fmt.Sprintf("symbol %s not found in file %s", binding.CodeSymbol, binding.CodeFile),
+				DocLoc:     types.Location{File: binding.DocFile, Line: binding.DocLine},
+				Heading:    binding.Heading,
+				CodeLoc:    types.Location{File: binding.CodeFile, Line: 0},
+			}
+			continue
+		}
+		found = append(found, i)
+		codeLines[i] = codeLine
+		symbols = append(symbols, llm.BlockSymbol{
+			CodeFile:    binding.CodeFile,
+			CodeSymbol:  binding.CodeSymbol,
+			CodeContent: codeContent,
+		})
+	}
 
-	return result, nil
+	if len(symbols) == 0 {
+		return results, errs
+	}
+
+	budget := newBudget(e.cfg)
+	codes := make([]string, len(symbols))
+	for j := range symbols {
+		codes[j] = symbols[j].CodeContent
+	}
+	codes = fitCode(budget, codes...)
+	for j := range symbols {
+		symbols[j].CodeContent = codes[j]
+	}
+	chunks := llm.ChunkText(block[0].DocContent, docBudget(budget, codes...), llm.ChunkOverlapLines)
+
+	// chunkVerdicts[j] holds the verdicts on symbol j, one per chunk.
+	chunkVerdicts := make([][]*types.CheckResult, len(symbols))
+	for _, chunk := range chunks {
+		var verdicts []*types.CheckResult
+		if len(symbols) == 1 {
+			binding := block[found[0]]
+			result, err := e.llmClient.Analyze(ctx, llm.AnalyzeRequest{
+				DocContent:  chunk.Text,
+				CodeContent: symbols[0].CodeContent,
+				CodeSymbol:  binding.CodeSymbol,
+				CodeFile:    binding.CodeFile,
+			})
+			if err != nil {
+				return fail(found, err)
+			}
+			verdicts = []*types.CheckResult{result}
+		} else {
+			var err error
+			verdicts, err = e.llmClient.AnalyzeBlock(ctx, llm.BlockAnalyzeRequest{
+				DocContent: chunk.Text,
+				Symbols:    symbols,
+			})
+			if err != nil {
+				return fail(found, err)
+			}
+			if len(verdicts) != len(symbols) {
+				return fail(found, fmt.Errorf("got %d verdicts for %d symbols", len(verdicts), len(symbols)))
+			}
+		}
+		for j, v := range verdicts {
+			chunkVerdicts[j] = append(chunkVerdicts[j], v)
+		}
+	}
+
+	for j, i := range found {
+		result := *mergeChunks(chunkVerdicts[j])
+		binding := block[i]
+		result.DocLoc = types.Location{File: binding.DocFile, Line: binding.DocLine}
+		result.Heading = binding.Heading
+		result.CodeLoc = types.Location{File: binding.CodeFile, Line: codeLines[i], Symbol: binding.CodeSymbol}
+		results[i] = &result
+	}
+	return results, errs
 }
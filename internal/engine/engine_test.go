@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/baseline"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/graph"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestEngineCheckFile_MultipleBindings(t *testing.T) {
+	dir := t.TempDir()
+	code := filepath.Join(dir, "shop.go")
+	require.NoError(t, os.WriteFile(code, []byte(`package shop
+
+func PlaceOrder(total float64) float64 { return total + CalculateShipping(total) }
+
+func CalculateShipping(total float64) float64 { return 0 }
+`), 0644))
+	doc := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(doc, []byte(strings.ReplaceAll(`# Checkout
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="CODE" func="PlaceOrder" -->
+<!-- docuguard:bindCode path="CODE" func="CalculateShipping" -->
+<!-- docuguard:bindCode path="CODE" func="ApplyCoupon" -->
+PlaceOrder adds shipping from CalculateShipping to the total.
+<!-- docuguard:end -->
+`, "CODE", code)), 0644))
+
+	e := &Engine{
+		cfg:      &config.Config{},
+		goParser: parser.NewGoParser(),
+		llmClient: &llm.MockClient{BlockResults: []*types.CheckResult{
+			{Consistent: true, Confidence: 0.9},
+			{Consistent: false, Confidence: 0.9, Reason: "shipping is never free"},
+		}},
+	}
+	report, err := e.CheckFile(context.Background(), doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.TotalBindings)
+	require.Len(t, report.Results, 3)
+	assert.True(t, report.Results[0].Consistent)
+	assert.Equal(t, "PlaceOrder", report.Results[0].CodeLoc.Symbol)
+	assert.Equal(t, 3, report.Results[0].DocLoc.Line)
+
+	assert.False(t, report.Results[1].Consistent)
+	assert.Equal(t, "CalculateShipping", report.Results[1].CodeLoc.Symbol)
+	assert.Equal(t, 4, report.Results[1].DocLoc.Line)
+	assert.Equal(t, "shipping is never free", report.Results[1].Reason)
+
+	assert.False(t, report.Results[2].Consistent)
+	assert.Contains(t, report.Results[2].Reason, "symbol ApplyCoupon not found")
+	assert.Equal(t, 5, report.Results[2].DocLoc.Line)
+	assert.Equal(t, 2, report.Inconsistent)
+}
+
+func TestEngineCheckFile_ReportsFailures(t *testing.T) {
+	dir := t.TempDir()
+	code := filepath.Join(dir, "shop.go")
+	require.NoError(t, os.WriteFile(code, []byte("package shop\n\nfunc PlaceOrder() {}\n"), 0644))
+	doc := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(doc, []byte(strings.ReplaceAll(`# Checkout
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="CODE" func="PlaceOrder" -->
+PlaceOrder places the order.
+<!-- docuguard:end -->
+
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="MISSING" func="Refund" -->
+Refund returns the payment.
+<!-- docuguard:end -->
+`, "CODE", code)), 0644))
+
+	e := &Engine{
+		cfg:       &config.Config{},
+		goParser:  parser.NewGoParser(),
+		llmClient: &llm.MockClient{Err: errors.New("connection refused")},
+	}
+	report, err := e.CheckFile(context.Background(), doc)
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Results)
+	assert.Equal(t, 2, report.Errors)
+	require.Len(t, report.Failures, 2)
+	assert.Contains(t, report.Failures[0], "PlaceOrder")
+	assert.Contains(t, report.Failures[0], "connection refused")
+	assert.Contains(t, report.Failures[1], "Refund in ")
+}
+
+func TestEngineCheckFile_CodeBindings(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "api.md")
+	require.NoError(t, os.WriteFile(doc, []byte("# API\n\n## Shipping\n\nFree over $100.\n"), 0644))
+	code := filepath.Join(dir, "shop.go")
+	require.NoError(t, os.WriteFile(code, []byte(`package shop
+
+//docuguard:doc path="`+doc+`" heading="Shipping"
+func CalculateShipping(total float64) float64 { return 0 }
+`), 0644))
+
+	bindings, unresolved := graph.CodeBindings(parser.NewGoParser(), []string{code})
+	require.Empty(t, unresolved)
+	codeBindings := graph.New()
+	codeBindings.Add(bindings...)
+
+	e := &Engine{
+		cfg:          &config.Config{},
+		goParser:     parser.NewGoParser(),
+		llmClient:    &llm.MockClient{Result: &types.CheckResult{Consistent: false, Reason: "shipping is never free"}},
+		codeBindings: codeBindings,
+	}
+	report, err := e.CheckFile(context.Background(), doc)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "CalculateShipping", report.Results[0].CodeLoc.Symbol)
+	assert.Equal(t, 3, report.Results[0].DocLoc.Line)
+
+	// A rewrite of a section declared from Go replaces the whole section.
+	e.llmClient = &llm.MockClient{Rewrite: "## Shipping\n\nShipping is never free."}
+	result, err := e.ProposeFixes(context.Background(), report)
+	require.NoError(t, err)
+	require.Empty(t, result.Failed)
+	require.Len(t, result.Edits, 1)
+	assert.Equal(t, 3, result.Edits[0].StartLine)
+	assert.Equal(t, []string{"## Shipping", "", "Shipping is never free."}, result.Edits[0].Replacement)
+}
+
+func TestDeclaredPairs(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "api.md")
+	require.NoError(t, os.WriteFile(doc, []byte("# API\n\n## Fees\n\nA flat fee applies.\n"), 0644))
+	code := filepath.Join(dir, "shop.go")
+	require.NoError(t, os.WriteFile(code, []byte(`package shop
+
+//docuguard:doc path="`+doc+`" heading="Fees"
+func CalculateShipping(total float64) float64 { return 0 }
+
+//docuguard:doc path="`+doc+`" heading="Refunds"
+func Refund() {}
+`), 0644))
+
+	symbols := []types.ChangedSymbol{
+		{Name: "CalculateShipping", File: code, ChangeType: types.ChangeModified},
+		{Name: "Refund", File: code, ChangeType: types.ChangeModified},
+	}
+	declared, unresolved := DeclaredPairs(symbols)
+	require.Len(t, declared, 1)
+	require.Len(t, unresolved, 1)
+	assert.Equal(t, "Fees", declared[0].Segment.Heading)
+	assert.Equal(t, "CalculateShipping", declared[0].Symbol.Name)
+
+	// The section does not mention the symbol, so only the directive pairs them.
+	matched := []types.RelevanceResult{{Segment: declared[0].Segment, Symbol: symbols[0]}}
+	assert.Len(t, MergePairs(nil, declared), 1)
+	assert.Len(t, MergePairs(matched, declared), 1, "pairs found by matching are not repeated")
+}
+
+func TestMarkBaselined_SameSymbolInTwoSections(t *testing.T) {
+	finding := func(heading, reason string) types.CheckResult {
+		return types.CheckResult{
+			DocLoc:  types.Location{File: "README.md"},
+			Heading: heading,
+			CodeLoc: types.Location{Symbol: "CalculateShipping"},
+			Reason:  reason,
+		}
+	}
+	b := baseline.FromReport(&types.Report{Results: []types.CheckResult{finding("Returns", "return shipping is free")}})
+
+	results := []types.CheckResult{
+		finding("Shipping", "threshold is 500, not 100"),
+		finding("Returns", "return shipping is no longer free"),
+	}
+	stale := markBaselined(b, results)
+
+	assert.False(t, results[0].Baselined, "a finding in another section is not baselined")
+	assert.True(t, results[1].Baselined)
+	assert.Empty(t, stale)
+}
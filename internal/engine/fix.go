@@ -0,0 +1,240 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/fix"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// FixResult holds the edits proposed for the inconsistencies of a report.
+type FixResult struct {
+	Edits []*fix.Edit
+	// Failed explains the inconsistencies for which no valid edit was produced.
+	Failed []error
+	// Usage is the LLM usage of the rewrites.
+	Usage *types.Usage
+}
+
+// collect gathers per-finding outcomes in report order.
+func (r *FixResult) collect(edits []*fix.Edit, errs []error) {
+	for i := range edits {
+		switch {
+		case edits[i] != nil:
+			r.Edits = append(r.Edits, edits[i])
+		case errs[i] != nil:
+			r.Failed = append(r.Failed, errs[i])
+		}
+	}
+}
+
+// ProposeFixes asks the LLM to rewrite the binding blocks of the report's
+// inconsistencies that are not baselined. A block bound to several symbols
+// is rewritten once, with the code and findings of every inconsistent one.
+func (e *Engine) ProposeFixes(ctx context.Context, report *types.Report) (*FixResult, error) {
+	startUsage := e.meter.Usage()
+	bindingsByFile := make(map[string][]types.Binding)
+	var targets []*blockFix
+	byBlock := make(map[string]*blockFix)
+	for _, r := range report.Results {
+		if r.Consistent || r.Baselined {
+			continue
+		}
+		if _, ok := bindingsByFile[r.DocLoc.File]; !ok {
+			bindings, err := e.bindings(r.DocLoc.File)
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract bindings: %w", err)
+			}
+			bindingsByFile[r.DocLoc.File] = bindings
+		}
+
+		binding := findBinding(bindingsByFile[r.DocLoc.File], r.DocLoc.Line)
+		key := fmt.Sprintf("%s:%d", r.DocLoc.File, r.DocLoc.Line)
+		if binding != nil {
+			key = fmt.Sprintf("%s:%d", binding.DocFile, binding.BlockLine)
+		}
+		if t, ok := byBlock[key]; ok && binding != nil {
+			t.add(*binding, r)
+			continue
+		}
+		t := &blockFix{first: r}
+		if binding != nil {
+			t.add(*binding, r)
+		}
+		byBlock[key] = t
+		targets = append(targets, t)
+	}
+
+	edits := make([]*fix.Edit, len(targets))
+	errs := make([]error, len(targets))
+	err := forEach(ctx, len(targets), e.cfg.LLM.MaxConcurrency, func(i int) {
+		t := targets[i]
+		edits[i], errs[i] = e.fixBlock(ctx, t)
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s:%d (%s): %w", t.first.DocLoc.File, t.first.DocLoc.Line, t.first.CodeLoc.Symbol, errs[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FixResult{Usage: usageSince(e.meter, startUsage)}
+	result.collect(edits, errs)
+	return result, nil
+}
+
+// blockFix gathers the inconsistent bindings of one block.
+type blockFix struct {
+	first    types.CheckResult
+	bindings []types.Binding
+	results  []types.CheckResult
+}
+
+func (t *blockFix) add(b types.Binding, r types.CheckResult) {
+	t.bindings = append(t.bindings, b)
+	t.results = append(t.results, r)
+}
+
+// findBinding returns the binding declared at a bindCode line.
+func findBinding(bindings []types.Binding, line int) *types.Binding {
+	for i := range bindings {
+		if bindings[i].DocLine == line {
+			return &bindings[i]
+		}
+	}
+	return nil
+}
+
+func (e *Engine) fixBlock(ctx context.Context, t *blockFix) (*fix.Edit, error) {
+	if len(t.bindings) == 0 {
+		return nil, fix.ErrStale
+	}
+
+	var files, symbols, codes, reasons, suggestions []string
+	for i, binding := range t.bindings {
+		code, _, err := e.goParser.ExtractSymbol(binding.CodeFile, binding.CodeSymbol, binding.CodeType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract code: %w", err)
+		}
+		if code == "" {
+			return nil, fmt.Errorf("symbol %s not found in file %s", binding.CodeSymbol, binding.CodeFile)
+		}
+		files = appendUnique(files, binding.CodeFile)
+		symbols = append(symbols, binding.CodeSymbol)
+		codes = append(codes, code)
+		reasons = append(reasons, t.results[i].Reason)
+		if t.results[i].Suggestion != "" {
+			suggestions = append(suggestions, t.results[i].Suggestion)
+		}
+	}
+
+	// A rewrite has to see the whole block, so it cannot be chunked.
+	binding := t.bindings[0]
+	budget := newBudget(e.cfg)
+	codes = fitCode(budget, codes...)
+	if err := checkRewriteSize(budget, binding.DocContent, codes...); err != nil {
+		return nil, err
+	}
+	rewritten, err := e.llmClient.RewriteDoc(ctx, llm.RewriteRequest{
+		DocFile:     binding.DocFile,
+		DocContent:  binding.DocContent,
+		CodeFile:    strings.Join(files, ", "),
+		CodeSymbol:  strings.Join(symbols, ", "),
+		CodeContent: strings.Join(codes, "\n\n"),
+		Reason:      strings.Join(reasons, "; "),
+		Suggestion:  strings.Join(suggestions, "; "),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("LLM rewrite failed: %w", err)
+	}
+
+	// A binding declared in Go points at a whole section.
+	var edit *fix.Edit
+	if binding.Segment != nil {
+		edit, err = fix.SegmentEdit(*binding.Segment, rewritten)
+	} else {
+		edit, err = fix.BindingEdit(binding, rewritten)
+	}
+	if err != nil {
+		return nil, err
+	}
+	edit.Symbol, edit.Reason = strings.Join(symbols, ", "), strings.Join(reasons, "; ")
+	return edit, nil
+}
+
+// appendUnique appends s unless list already holds it.
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// ProposeFixes asks the LLM to rewrite the documentation sections of the
+// report's inconsistencies that are not baselined. A section found
+// inconsistent with several symbols is rewritten once, for the first.
+func (e *PREngine) ProposeFixes(ctx context.Context, report *types.PRReport) (*FixResult, error) {
+	startUsage := e.meter.Usage()
+	var targets []types.PRCheckResult
+	seen := make(map[string]bool)
+	for _, r := range report.Results {
+		if r.Consistent || r.Baselined || r.Status == types.StatusError {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", r.Segment.File, r.Segment.StartLine)
+		if !seen[key] {
+			seen[key] = true
+			targets = append(targets, r)
+		}
+	}
+
+	edits := make([]*fix.Edit, len(targets))
+	errs := make([]error, len(targets))
+	err := forEach(ctx, len(targets), e.cfg.LLM.MaxConcurrency, func(i int) {
+		r := targets[i]
+		edits[i], errs[i] = e.fixSegment(ctx, r)
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s:%d (%s): %w", r.Segment.File, r.Segment.StartLine, r.Symbol.Name, errs[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FixResult{Usage: usageSince(e.meter, startUsage)}
+	result.collect(edits, errs)
+	return result, nil
+}
+
+func (e *PREngine) fixSegment(ctx context.Context, r types.PRCheckResult) (*fix.Edit, error) {
+	budget := newBudget(e.cfg)
+	code := fitCode(budget, r.Symbol.OldCode, r.Symbol.NewCode)
+	if err := checkRewriteSize(budget, r.Segment.Content, code...); err != nil {
+		return nil, err
+	}
+	rewritten, err := e.llmClient.RewriteDoc(ctx, llm.RewriteRequest{
+		DocFile:     r.Segment.File,
+		DocContent:  r.Segment.Content,
+		CodeFile:    r.Symbol.File,
+		CodeSymbol:  r.Symbol.Name,
+		OldCode:     code[0],
+		CodeContent: code[1],
+		Reason:      r.Reason,
+		Suggestion:  r.Suggestion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("LLM rewrite failed: %w", err)
+	}
+
+	edit, err := fix.SegmentEdit(r.Segment, rewritten)
+	if err != nil {
+		return nil, err
+	}
+	edit.Symbol, edit.Reason = r.Symbol.Name, r.Reason
+	return edit, nil
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestPREngineProposeFixes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Shop\n\n## Shipping\n\nFree shipping over $100.\n"), 0644))
+	segment := types.DocSegment{File: path, StartLine: 3, EndLine: 5, Heading: "Shipping", Level: 2,
+		Content: "## Shipping\n\nFree shipping over $100."}
+	symbol := types.ChangedSymbol{Name: "CalculateShipping"}
+
+	report := &types.PRReport{Results: []types.PRCheckResult{
+		{Segment: segment, Symbol: symbol, Reason: "threshold changed"},
+		{Segment: segment, Symbol: types.ChangedSymbol{Name: "ShippingFee"}, Reason: "same section"},
+		{Segment: segment, Symbol: symbol, Baselined: true},
+		{Segment: segment, Symbol: symbol, Status: types.StatusError},
+	}}
+
+	e := &PREngine{
+		cfg:       &config.Config{},
+		llmClient: &llm.MockClient{Rewrite: "## Shipping\n\nFree shipping over $500."},
+	}
+	result, err := e.ProposeFixes(context.Background(), report)
+	require.NoError(t, err)
+	assert.Empty(t, result.Failed)
+	require.Len(t, result.Edits, 1, "a section is rewritten once")
+	assert.Equal(t, []string{"Free shipping over $500."}, result.Edits[0].Replacement[2:])
+	assert.Equal(t, "CalculateShipping", result.Edits[0].Symbol)
+
+	e.llmClient = &llm.MockClient{Rewrite: "## Delivery\n\nFree shipping over $500."}
+	result, err = e.ProposeFixes(context.Background(), report)
+	require.NoError(t, err)
+	assert.Empty(t, result.Edits)
+	require.Len(t, result.Failed, 1)
+	assert.ErrorContains(t, result.Failed[0], "heading")
+}
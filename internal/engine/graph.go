@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/glob"
+	"github.com/blueberrycongee/docuguard/internal/graph"
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// loadCodeBindings resolves the //docuguard:doc directives of the Go files
+// matched by scan.code. Directives that do not resolve are returned as
+// errors so the caller can warn about them.
+func loadCodeBindings(cfg *config.Config, goParser *parser.GoParser) (*graph.Graph, []error, error) {
+	g := graph.New()
+	if len(cfg.Scan.Code) == 0 {
+		return g, nil, nil
+	}
+
+	files, err := glob.Files(".", cfg.Scan.Code, glob.Options{
+		Exclude:   cfg.Scan.Exclude,
+		GitIgnore: cfg.Scan.GitIgnore,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to expand code patterns: %w", err)
+	}
+	var sources []string
+	for _, file := range files {
+		if auditedSource(filepath.ToSlash(file)) {
+			sources = append(sources, file)
+		}
+	}
+
+	bindings, unresolved := graph.CodeBindings(goParser, sources)
+	g.Add(bindings...)
+	return g, unresolved, nil
+}
+
+// bindings returns the bindings of a documentation file: its bindCode
+// directives merged with the //docuguard:doc directives pointing at it.
+func (e *Engine) bindings(docPath string) ([]types.Binding, error) {
+	bindings, err := parser.ExtractBindings(docPath)
+	if err != nil {
+		return nil, err
+	}
+	if e.codeBindings == nil {
+		return bindings, nil
+	}
+
+	g := graph.New()
+	g.Add(bindings...)
+	g.Add(e.codeBindings.ForDoc(docPath)...)
+	return g.ForDoc(docPath), nil
+}
+
+// Unresolved returns the //docuguard:doc directives that could not be
+// resolved to a documentation section.
+func (e *Engine) Unresolved() []error {
+	return e.unresolved
+}
+
+// DeclaredPairs returns the pairs declared by //docuguard:doc directives on
+// the changed symbols, which are checked whether or not the documentation
+// mentions the symbol. Deleted symbols have no declaration left to read.
+// Directives that do not resolve to a section are returned as errors.
+func DeclaredPairs(symbols []types.ChangedSymbol) ([]types.RelevanceResult, []error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, sym := range symbols {
+		if sym.ChangeType == types.ChangeDeleted || seen[sym.File] {
+			continue
+		}
+		seen[sym.File] = true
+		if _, err := os.Stat(sym.File); err == nil {
+			files = append(files, sym.File)
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	bindings, unresolved := graph.CodeBindings(parser.NewGoParser(), files)
+	g := graph.New()
+	g.Add(bindings...)
+
+	var pairs []types.RelevanceResult
+	for _, sym := range symbols {
+		if sym.ChangeType == types.ChangeDeleted {
+			continue
+		}
+		for _, b := range g.ForSymbol(sym.File, sym.Name) {
+			if parser.PairSuppression(*b.Segment, sym) != nil {
+				continue
+			}
+			pairs = append(pairs, types.RelevanceResult{
+				Segment:    *b.Segment,
+				Symbol:     sym,
+				IsRelevant: true,
+				Confidence: 1.0,
+				Reason:     "declared by //docuguard:doc",
+			})
+		}
+	}
+	return pairs, unresolved
+}
+
+// MergePairs appends the declared pairs that matching did not already find.
+func MergePairs(pairs, declared []types.RelevanceResult) []types.RelevanceResult {
+	key := func(p types.RelevanceResult) string {
+		return fmt.Sprintf("%s:%d:%s:%s", filepath.Clean(p.Segment.File), p.Segment.StartLine,
+			filepath.Clean(p.Symbol.File), p.Symbol.Name)
+	}
+	seen := make(map[string]bool)
+	for _, p := range pairs {
+		seen[key(p)] = true
+	}
+	for _, p := range declared {
+		if !seen[key(p)] {
+			seen[key(p)] = true
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
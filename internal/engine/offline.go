@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/baseline"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/drift"
+	"github.com/blueberrycongee/docuguard/internal/matcher"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// driftConfidence is the confidence of a literal-drift finding. The match is
+// exact but the doc may quote the same number in an unrelated sentence.
+const driftConfidence = 0.9
+
+// checkOffline checks a pair without an LLM: the documentation is
+// inconsistent when it still quotes a literal the change removed.
+func checkOffline(segment types.DocSegment, symbol types.ChangedSymbol) types.PRCheckResult {
+	result := types.PRCheckResult{
+		Segment:    segment,
+		Symbol:     symbol,
+		Related:    true,
+		Consistent: true,
+		Confidence: 0.5,
+		Reason:     "Keyword match only, no changed literal is quoted in the documentation",
+		Status:     types.StatusChecked,
+	}
+
+	findings := drift.Detect(segment, symbol)
+	if len(findings) == 0 {
+		return result
+	}
+
+	reasons := make([]string, len(findings))
+	suggestions := make([]string, len(findings))
+	for i, f := range findings {
+		reasons[i] = f.Reason()
+		suggestions[i] = f.Suggestion()
+	}
+
+	result.Consistent = false
+	result.Confidence = driftConfidence
+	result.Reason = strings.Join(reasons, "; ")
+	result.Suggestion = strings.Join(suggestions, "; ")
+	result.Line = findings[0].Line
+	return result
+}
+
+// OfflineReport checks matched pairs without an LLM, using literal-drift
+// detection, and classifies the results with the configured rules and baseline.
+func OfflineReport(cfg *config.Config, symbols []types.ChangedSymbol, segments []types.DocSegment, pairs []types.RelevanceResult) (*types.PRReport, error) {
+	b, err := loadBaseline(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.PRReport{
+		TotalSymbols:  len(symbols),
+		TotalSegments: len(segments),
+		RelevantPairs: len(pairs),
+		Results:       make([]types.PRCheckResult, len(pairs)),
+	}
+	for i, pair := range pairs {
+		report.Results[i] = checkOffline(pair.Segment, pair.Symbol)
+	}
+	report.Suppressed = matcher.Suppressed(symbols, segments)
+	summarizePR(cfg.Rules, b, report)
+	return report, nil
+}
+
+// summarizePR applies the baseline, assigns severities and counts
+// inconsistencies, blocking findings, baselined findings and failed checks.
+func summarizePR(rules config.RuleConfig, b *baseline.Baseline, report *types.PRReport) {
+	report.StaleBaseline = markBaselinedPR(b, report.Results)
+
+	for i := range report.Results {
+		result := &report.Results[i]
+		switch result.Status {
+		case types.StatusError:
+			report.Errors++
+			continue
+		case types.StatusSkipped:
+			report.Skipped++
+			continue
+		}
+
+		result.Severity = classify(rules, result.Consistent, result.Confidence)
+		if result.Baselined {
+			report.Baselined++
+		} else if !result.Consistent {
+			report.Inconsistent++
+			if blocks(rules, result.Severity) {
+				report.Blocking++
+			}
+		}
+	}
+}
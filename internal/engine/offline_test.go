@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/baseline"
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/matcher"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestOfflineReport(t *testing.T) {
+	symbol := types.ChangedSymbol{
+		File:    "shop/shipping.go",
+		Name:    "CalculateShipping",
+		OldCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 100 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+		NewCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 500 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+	}
+	stale := types.DocSegment{File: "README.md", StartLine: 20, Content: "## Shipping\nFree shipping over $100."}
+	current := types.DocSegment{File: "README.md", StartLine: 30, Content: "## CalculateShipping\nComputes the fee."}
+
+	cfg := &config.Config{Rules: config.RuleConfig{ConfidenceThreshold: 0.8, SeverityThreshold: "warning"}}
+	pairs := []types.RelevanceResult{
+		{Segment: stale, Symbol: symbol},
+		{Segment: current, Symbol: symbol},
+	}
+
+	report, err := OfflineReport(cfg, []types.ChangedSymbol{symbol}, []types.DocSegment{stale, current}, pairs)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, 1, report.Inconsistent)
+	assert.Equal(t, 1, report.Blocking)
+
+	finding := report.Results[0]
+	assert.False(t, finding.Consistent)
+	assert.Equal(t, 21, finding.Line)
+	assert.Equal(t, types.SeverityError, finding.Severity)
+	assert.Equal(t, "doc says 100 (line 21), code now uses 500", finding.Reason)
+
+	assert.True(t, report.Results[1].Consistent)
+	assert.Equal(t, types.SeverityInfo, report.Results[1].Severity)
+}
+
+func TestOfflineReportBaseline(t *testing.T) {
+	symbol := types.ChangedSymbol{
+		File:    "shop/shipping.go",
+		Name:    "CalculateShipping",
+		OldCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 100 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+		NewCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 500 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+	}
+	stale := types.DocSegment{File: "README.md", Heading: "Shipping", StartLine: 20, Content: "## Shipping\nFree shipping over $100."}
+	current := types.DocSegment{File: "README.md", Heading: "CalculateShipping", StartLine: 30, Content: "## CalculateShipping\nComputes the fee."}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	b := &baseline.Baseline{}
+	b.Add(baseline.Finding{DocFile: "README.md", Heading: "Shipping", Symbol: "CalculateShipping",
+		Reason: "doc says 100 (line 21), code now uses 500"})
+	b.Add(baseline.Finding{DocFile: "README.md", Heading: "CalculateShipping", Symbol: "CalculateShipping",
+		Reason: "doc says 5 retries, code now uses 3"})
+	require.NoError(t, b.Save(path))
+
+	cfg := &config.Config{Rules: config.RuleConfig{ConfidenceThreshold: 0.8, SeverityThreshold: "warning", Baseline: path}}
+	pairs := []types.RelevanceResult{
+		{Segment: stale, Symbol: symbol},
+		{Segment: current, Symbol: symbol},
+	}
+
+	report, err := OfflineReport(cfg, []types.ChangedSymbol{symbol}, []types.DocSegment{stale, current}, pairs)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Inconsistent)
+	assert.Equal(t, 0, report.Blocking)
+	assert.Equal(t, 1, report.Baselined)
+	assert.True(t, report.Results[0].Baselined)
+
+	require.Len(t, report.StaleBaseline, 1)
+	assert.Equal(t, "CalculateShipping", report.StaleBaseline[0].Heading)
+}
+
+func TestOfflineReportSuppressed(t *testing.T) {
+	symbol := types.ChangedSymbol{
+		File:    "shop/shipping.go",
+		Name:    "CalculateShipping",
+		OldCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 100 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+		NewCode: "func CalculateShipping(amount float64) float64 {\n\tif amount >= 500 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+	}
+	segment := types.DocSegment{
+		File: "README.md", Heading: "Shipping", StartLine: 20,
+		Content:      "## Shipping\nCalculateShipping is free over $100.",
+		Suppressions: []types.Suppression{{Symbol: "CalculateShipping", Reason: "legacy plan", File: "README.md", Line: 21}},
+	}
+	segments := []types.DocSegment{segment}
+	symbols := []types.ChangedSymbol{symbol}
+
+	pairs := matcher.QuickMatch(symbols, segments)
+	assert.Empty(t, pairs, "suppressed pairs are not matched")
+
+	report, err := OfflineReport(&config.Config{}, symbols, segments, pairs)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Inconsistent)
+	require.Len(t, report.Suppressed, 1)
+	assert.Equal(t, "Shipping", report.Suppressed[0].Heading)
+	assert.Equal(t, "legacy plan", report.Suppressed[0].Suppression.Reason)
+}
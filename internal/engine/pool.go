@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultConcurrency is used when no positive limit is configured.
+const defaultConcurrency = 4
+
+// forEach calls fn for every index in [0, n) using at most limit goroutines.
+// Callers write results into pre-sized slices by index, which keeps output
+// order independent of completion order. Once ctx is canceled no new work
+// is started and forEach returns ctx.Err() after in-flight calls finish.
+func forEach(ctx context.Context, n, limit int, fn func(i int)) error {
+	if limit <= 0 {
+		limit = defaultConcurrency
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
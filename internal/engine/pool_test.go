@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach_BoundedAndOrdered(t *testing.T) {
+	var running, peak int32
+	results := make([]int, 20)
+
+	err := forEach(context.Background(), len(results), 3, func(i int) {
+		cur := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		results[i] = i * i
+		atomic.AddInt32(&running, -1)
+	})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak, int32(3))
+	for i, v := range results {
+		assert.Equal(t, i*i, v)
+	}
+}
+
+func TestForEach_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+
+	err := forEach(ctx, 100, 1, func(i int) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			cancel()
+		}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, calls, int32(100))
+}
@@ -2,10 +2,14 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/blueberrycongee/docuguard/internal/baseline"
 	"github.com/blueberrycongee/docuguard/internal/config"
 	"github.com/blueberrycongee/docuguard/internal/git"
+	"github.com/blueberrycongee/docuguard/internal/glob"
 	"github.com/blueberrycongee/docuguard/internal/llm"
 	"github.com/blueberrycongee/docuguard/internal/matcher"
 	"github.com/blueberrycongee/docuguard/internal/scanner"
@@ -16,11 +20,15 @@ import (
 type PREngine struct {
 	cfg       *config.Config
 	llmClient llm.Client
+	baseline  *baseline.Baseline
+	// meter counts the LLM usage of the engine's calls.
+	meter *llm.Meter
 }
 
 // PRCheckOptions contains options for PR checking.
 type PRCheckOptions struct {
-	// BaseBranch is the base branch for comparison.
+	// BaseBranch is the base revision for comparison. Old versions of
+	// changed files are read from it to extract complete declarations.
 	BaseBranch string
 	// DocPatterns are glob patterns for documentation files.
 	DocPatterns []string
@@ -32,12 +40,12 @@ type PRCheckOptions struct {
 
 // NewPREngine creates a new PREngine with the given configuration.
 func NewPREngine(cfg *config.Config) (*PREngine, error) {
-	client, err := llm.NewClient(
-		cfg.LLM.Provider,
-		cfg.LLM.Model,
-		cfg.LLM.APIKey,
-		cfg.LLM.BaseURL,
-	)
+	client, meter, err := newLLMClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := loadBaseline(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -45,15 +53,18 @@ func NewPREngine(cfg *config.Config) (*PREngine, error) {
 	return &PREngine{
 		cfg:       cfg,
 		llmClient: client,
+		baseline:  b,
+		meter:     meter,
 	}, nil
 }
 
 // CheckFromDiff performs a consistency check from diff content.
 func (e *PREngine) CheckFromDiff(ctx context.Context, diffContent string, opts PRCheckOptions) (*types.PRReport, error) {
 	startTime := time.Now()
+	startUsage := e.meter.Usage()
 	report := &types.PRReport{}
 
-	extractor := git.NewSymbolExtractor()
+	extractor := git.NewSymbolExtractor(git.NewGitSources(opts.BaseBranch))
 	symbols, err := extractor.ExtractChangedSymbols(diffContent)
 	if err != nil {
 		return nil, err
@@ -65,7 +76,10 @@ func (e *PREngine) CheckFromDiff(ctx context.Context, diffContent string, opts P
 		return report, nil
 	}
 
-	segments, err := scanner.ScanMarkdownDir(".", opts.DocPatterns)
+	segments, err := scanner.ScanMarkdownDir(".", opts.DocPatterns, glob.Options{
+		Exclude:   e.cfg.Scan.Exclude,
+		GitIgnore: e.cfg.Scan.GitIgnore,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -75,75 +89,167 @@ func (e *PREngine) CheckFromDiff(ctx context.Context, diffContent string, opts P
 
 	if opts.UseTwoStage && !opts.SkipLLM {
 		// Two-stage matching: broad match + LLM relevance filter
-		relevantPairs = e.twoStageMatch(ctx, symbols, segments)
+		var failed []error
+		relevantPairs, failed, err = e.twoStageMatch(ctx, symbols, segments, true)
+		if err != nil {
+			return nil, err
+		}
+		report.Skipped = budgetSkipped(failed)
 	} else {
 		// Original quick match
 		relevantPairs = matcher.QuickMatch(symbols, segments)
 	}
+
+	// Documentation declared on a changed declaration is always checked.
+	// Callers warn about unresolved directives with DeclaredPairs.
+	declared, _ := DeclaredPairs(symbols)
+	relevantPairs = MergePairs(relevantPairs, declared)
 	report.RelevantPairs = len(relevantPairs)
+	report.Suppressed = matcher.Suppressed(symbols, segments)
 
-	for _, pair := range relevantPairs {
-		result := e.checkConsistency(ctx, pair.Segment, pair.Symbol, opts.SkipLLM)
-		report.Results = append(report.Results, result)
-		if !result.Consistent {
-			report.Inconsistent++
-		}
+	report.Results = make([]types.PRCheckResult, len(relevantPairs))
+	err = forEach(ctx, len(relevantPairs), e.cfg.LLM.MaxConcurrency, func(i int) {
+		pair := relevantPairs[i]
+		report.Results[i] = e.checkConsistency(ctx, pair.Segment, pair.Symbol, opts.SkipLLM)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	summarizePR(e.cfg.Rules, e.baseline, report)
+
+	report.Usage = usageSince(e.meter, startUsage)
 	report.ExecutionTimeMs = time.Since(startTime).Milliseconds()
 	return report, nil
 }
 
+// RelevanceError reports a failed relevance check whose candidates were
+// dropped instead of confirmed.
+type RelevanceError struct {
+	// Symbol is the symbol the candidates were matched to.
+	Symbol types.ChangedSymbol
+	// Candidates is the number of candidates dropped.
+	Candidates int
+	// Err is the error of the relevance check.
+	Err error
+}
+
+func (e *RelevanceError) Error() string {
+	return fmt.Sprintf("relevance check for %s failed, %d candidate(s) dropped: %v", e.Symbol.Name, e.Candidates, e.Err)
+}
+
+func (e *RelevanceError) Unwrap() error {
+	return e.Err
+}
+
 // twoStageMatch performs two-stage matching:
 // Stage 1: Broad keyword matching to find candidates
 // Stage 2: LLM batch relevance check to filter candidates
-func (e *PREngine) twoStageMatch(ctx context.Context, symbols []types.ChangedSymbol, segments []types.DocSegment) []types.RelevanceResult {
+// Symbol groups are checked concurrently; results keep the order in which
+// symbols first appear among the candidates. Candidates of a symbol are sent
+// in as many requests as the model's context window requires.
+// When a relevance check fails, keepFailed keeps its candidates as relevant,
+// which errs toward checking too much; otherwise they are dropped and the
+// failure is returned as a RelevanceError. Candidates left unfiltered
+// because the LLM budget ran out are always dropped, since they could not
+// be checked either.
+func (e *PREngine) twoStageMatch(ctx context.Context, symbols []types.ChangedSymbol, segments []types.DocSegment, keepFailed bool) ([]types.RelevanceResult, []error, error) {
 	// Stage 1: Broad match to get candidates
 	candidates := matcher.BroadMatch(symbols, segments)
 	if len(candidates) == 0 {
-		return nil
+		return nil, nil, nil
 	}
 
 	// Group candidates by symbol for batch processing
 	groups := matcher.GroupCandidatesBySymbol(candidates)
-
-	var results []types.RelevanceResult
+	var keys []string
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		key := c.Symbol.File + ":" + c.Symbol.Name
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
 
 	// Stage 2: LLM relevance check for each symbol
-	for _, group := range groups {
+	budget := newBudget(e.cfg)
+	groupResults := make([][]types.RelevanceResult, len(keys))
+	groupErrs := make([][]error, len(keys))
+	err := forEach(ctx, len(keys), e.cfg.LLM.MaxConcurrency, func(i int) {
+		group := groups[keys[i]]
 		if len(group) == 0 {
-			continue
+			return
 		}
 
+		// The prompt of a changed symbol shows its code before the change too.
 		symbol := group[0].Symbol
+		code := []string{symbol.NewCode}
+		if symbol.OldCode != "" {
+			code = append(code, symbol.OldCode)
+		}
+		code = fitCode(budget, code...)
+		symbol.NewCode = code[0]
+		if len(code) > 1 {
+			symbol.OldCode = code[1]
+		}
 		candidateSegments := make([]types.DocSegment, len(group))
-		for i, c := range group {
-			candidateSegments[i] = c.Segment
+		for j, c := range group {
+			candidateSegments[j] = c.Segment
 		}
 
-		// Batch relevance check
-		req := llm.RelevanceRequest{
-			Symbol:     symbol,
-			Candidates: candidateSegments,
-		}
+		for _, batch := range relevanceBatches(budget, candidateSegments, code...) {
+			// Batch relevance check
+			req := llm.RelevanceRequest{
+				Symbol:     symbol,
+				Candidates: candidateSegments[batch[0]:batch[1]],
+			}
 
-		relevantIndices, err := e.llmClient.CheckRelevanceBatch(ctx, req)
-		if err != nil {
-			// On error, include all candidates (conservative approach)
-			results = append(results, group...)
-			continue
-		}
+			relevantIndices, err := e.llmClient.CheckRelevanceBatch(ctx, req)
+			if err != nil {
+				if keepFailed && !errors.Is(err, llm.ErrBudgetExceeded) {
+					// On error, include all candidates (conservative approach)
+					groupResults[i] = append(groupResults[i], group[batch[0]:batch[1]]...)
+				} else {
+					groupErrs[i] = append(groupErrs[i], &RelevanceError{Symbol: symbol, Candidates: batch[1] - batch[0], Err: err})
+				}
+				continue
+			}
 
-		// Only include relevant candidates
-		for _, idx := range relevantIndices {
-			if idx >= 0 && idx < len(group) {
-				group[idx].Reason = "LLM confirmed relevant"
-				results = append(results, group[idx])
+			// Only include relevant candidates
+			for _, idx := range relevantIndices {
+				if idx >= 0 && idx < batch[1]-batch[0] {
+					c := group[batch[0]+idx]
+					c.Reason = "LLM confirmed relevant (" + c.Reason + ")"
+					groupResults[i] = append(groupResults[i], c)
+				}
 			}
 		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var results []types.RelevanceResult
+	var failed []error
+	for i, r := range groupResults {
+		results = append(results, r...)
+		failed = append(failed, groupErrs[i]...)
 	}
+	return results, failed, nil
+}
 
-	return results
+// budgetSkipped counts the candidates two-stage matching dropped because
+// the LLM budget ran out.
+func budgetSkipped(failed []error) int {
+	n := 0
+	for _, err := range failed {
+		var relErr *RelevanceError
+		if errors.As(err, &relErr) && errors.Is(relErr.Err, llm.ErrBudgetExceeded) {
+			n += relErr.Candidates
+		}
+	}
+	return n
 }
 
 // checkConsistency checks consistency between a document segment and code symbol.
@@ -156,31 +262,37 @@ func (e *PREngine) checkConsistency(
 	result := types.PRCheckResult{
 		Segment: segment,
 		Symbol:  symbol,
+		Status:  types.StatusChecked,
 	}
 
 	if skipLLM {
-		result.Related = true
-		result.Consistent = true
-		result.Confidence = 0.5
-		result.Reason = "Keyword match only, LLM check skipped"
-		return result
+		return checkOffline(segment, symbol)
 	}
 
-	req := llm.AnalyzeRequest{
-		DocContent:  segment.Content,
-		CodeContent: symbol.NewCode,
-		CodeSymbol:  symbol.Name,
-		CodeFile:    symbol.File,
-	}
+	// The model sees the code before and after the change, so its verdict
+	// can point at the part of the diff that invalidated the documentation.
+	// A section too large for one request is checked in overlapping chunks.
+	budget := newBudget(e.cfg)
+	code := fitCode(budget, symbol.OldCode, symbol.NewCode)
+	symbol.OldCode, symbol.NewCode = code[0], code[1]
+	chunks := llm.ChunkSegment(segment, docBudget(budget, code...), llm.ChunkOverlapLines)
 
-	llmResult, err := e.llmClient.Analyze(ctx, req)
-	if err != nil {
-		result.Related = true
-		result.Consistent = true
-		result.Confidence = 0.0
-		result.Reason = "LLM check failed: " + err.Error()
-		return result
+	verdicts := make([]*types.CheckResult, len(chunks))
+	for i, chunk := range chunks {
+		req := llm.PRAnalyzeRequest{
+			Segment: chunk,
+			Symbol:  symbol,
+		}
+
+		var err error
+		if verdicts[i], err = e.llmClient.AnalyzePR(ctx, req); err != nil {
+			if errors.Is(err, llm.ErrBudgetExceeded) {
+				return skippedResult(result)
+			}
+			return failedResult(result, err)
+		}
 	}
+	llmResult := mergeChunks(verdicts)
 
 	result.Related = llmResult.Related
 	result.Consistent = llmResult.Consistent
@@ -190,3 +302,26 @@ func (e *PREngine) checkConsistency(
 
 	return result
 }
+
+// failedResult marks a result whose LLM check failed. A failed check is
+// neither a pass nor a finding; it is reported separately.
+func failedResult(result types.PRCheckResult, err error) types.PRCheckResult {
+	result.Status = types.StatusError
+	result.Related = true
+	result.Consistent = false
+	result.Confidence = 0.0
+	result.Reason = "LLM check failed"
+	result.Error = err.Error()
+	return result
+}
+
+// skippedResult marks a pair left unchecked because the LLM budget of the
+// run was used up. Like a failed check, it is neither a pass nor a finding.
+func skippedResult(result types.PRCheckResult) types.PRCheckResult {
+	result.Status = types.StatusSkipped
+	result.Related = false
+	result.Consistent = true
+	result.Confidence = 0.0
+	result.Reason = "Not checked: LLM budget exceeded"
+	return result
+}
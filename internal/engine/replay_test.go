@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// shippingChange is the change recorded in testdata/pr_transcript.json.
+func shippingChange() (types.DocSegment, types.ChangedSymbol) {
+	segment := types.DocSegment{
+		File: "docs/shop.md", StartLine: 3, EndLine: 5, Heading: "Shipping", Level: 2, Type: "markdown",
+		Content: "## Shipping\n\nOrders over $100 ship free.",
+	}
+	symbol := types.ChangedSymbol{
+		Name: "CalculateShipping", Type: types.BindingFunc, File: "shop/shipping.go",
+		ChangeType: types.ChangeModified, StartLine: 3, EndLine: 8,
+		OldCode: "func CalculateShipping(total float64) float64 {\n\tif total >= 100 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+		NewCode: "func CalculateShipping(total float64) float64 {\n\tif total >= 500 {\n\t\treturn 0\n\t}\n\treturn 10\n}",
+	}
+	return segment, symbol
+}
+
+func TestPREngineReplay(t *testing.T) {
+	client, err := llm.NewReplayClient("testdata/pr_transcript.json")
+	require.NoError(t, err)
+	e := &PREngine{cfg: &config.Config{Rules: config.RuleConfig{ConfidenceThreshold: 0.8}}, llmClient: client}
+
+	segment, symbol := shippingChange()
+	report := &types.PRReport{Results: []types.PRCheckResult{
+		e.checkConsistency(context.Background(), segment, symbol, false),
+	}}
+
+	// Any change to the request misses the transcript instead of reusing a verdict.
+	symbol.NewCode = "func CalculateShipping(total float64) float64 { return 0 }"
+	report.Results = append(report.Results, e.checkConsistency(context.Background(), segment, symbol, false))
+	summarizePR(e.cfg.Rules, nil, report)
+
+	assert.Equal(t, 1, report.Inconsistent)
+	assert.Equal(t, 1, report.Errors)
+
+	recorded := report.Results[0]
+	assert.Equal(t, types.StatusChecked, recorded.Status)
+	assert.False(t, recorded.Consistent)
+	assert.Equal(t, 0.95, recorded.Confidence)
+	assert.Equal(t, "The free-shipping threshold changed from $100 to $500.", recorded.Reason)
+	assert.Equal(t, types.SeverityError, recorded.Severity)
+
+	missing := report.Results[1]
+	assert.Equal(t, types.StatusError, missing.Status)
+	assert.Contains(t, missing.Error, "request not recorded in transcript")
+}
@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// severityRank orders severities from least to most severe.
+var severityRank = map[types.Severity]int{
+	types.SeverityInfo:    0,
+	types.SeverityWarning: 1,
+	types.SeverityError:   2,
+}
+
+// classify derives the severity of a verdict from rules.confidence_threshold.
+// Inconsistencies at or above the threshold are errors, those above half of
+// it are warnings, and anything less certain is informational.
+func classify(rules config.RuleConfig, consistent bool, confidence float64) types.Severity {
+	switch {
+	case consistent:
+		return types.SeverityInfo
+	case confidence >= rules.ConfidenceThreshold:
+		return types.SeverityError
+	case confidence >= rules.ConfidenceThreshold/2:
+		return types.SeverityWarning
+	default:
+		return types.SeverityInfo
+	}
+}
+
+// blocks reports whether an inconsistency of the given severity is at or
+// above rules.severity_threshold and should fail the run.
+// An unknown threshold falls back to "warning".
+func blocks(rules config.RuleConfig, sev types.Severity) bool {
+	threshold, ok := severityRank[types.Severity(strings.ToLower(rules.SeverityThreshold))]
+	if !ok {
+		threshold = severityRank[types.SeverityWarning]
+	}
+	rank, ok := severityRank[sev]
+	return ok && rank >= threshold
+}
@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestClassify(t *testing.T) {
+	rules := config.RuleConfig{ConfidenceThreshold: 0.8}
+
+	assert.Equal(t, types.SeverityInfo, classify(rules, true, 0.99))
+	assert.Equal(t, types.SeverityError, classify(rules, false, 0.8))
+	assert.Equal(t, types.SeverityWarning, classify(rules, false, 0.5))
+	assert.Equal(t, types.SeverityInfo, classify(rules, false, 0.3))
+}
+
+func TestBlocks(t *testing.T) {
+	warning := config.RuleConfig{SeverityThreshold: "warning"}
+	assert.True(t, blocks(warning, types.SeverityError))
+	assert.True(t, blocks(warning, types.SeverityWarning))
+	assert.False(t, blocks(warning, types.SeverityInfo))
+
+	errorOnly := config.RuleConfig{SeverityThreshold: "error"}
+	assert.False(t, blocks(errorOnly, types.SeverityWarning))
+
+	assert.True(t, blocks(config.RuleConfig{SeverityThreshold: "bogus"}, types.SeverityWarning))
+	assert.False(t, blocks(warning, ""))
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/fix"
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// SuggestOptions contains options for suggesting bindings.
+type SuggestOptions struct {
+	// MinConfidence drops suggestions whose broad-match score is lower.
+	MinConfidence float64
+	// MaxPerSection limits the suggestions per section, best first. Zero
+	// keeps them all.
+	MaxPerSection int
+}
+
+// BindingSuggestion proposes binding a documentation section to a symbol.
+type BindingSuggestion struct {
+	Symbol     types.ChangedSymbol `json:"symbol"`
+	Confidence float64             `json:"confidence"`
+	Reason     string              `json:"reason"`
+}
+
+// Directive returns the bindCode directive for the suggestion.
+func (s BindingSuggestion) Directive() string {
+	return parser.BindCode{Path: s.Symbol.File, Kind: string(s.Symbol.Type), Symbol: s.Symbol.Name}.String()
+}
+
+// SectionSuggestion holds the bindings proposed for one section.
+type SectionSuggestion struct {
+	Segment     types.DocSegment    `json:"segment"`
+	Suggestions []BindingSuggestion `json:"suggestions"`
+}
+
+// SuggestBindings proposes bindings for documentation sections that have
+// none. Candidates come from broad keyword matching between the symbols and
+// sections and are confirmed by the LLM relevance filter, as in two-stage
+// PR matching. Sections are returned in file and line order. Candidates
+// whose relevance check failed are never suggested; the failures are
+// returned as RelevanceErrors.
+func (e *PREngine) SuggestBindings(ctx context.Context, symbols []types.ChangedSymbol, segments []types.DocSegment, opts SuggestOptions) ([]SectionSuggestion, []error, error) {
+	var unbound []types.DocSegment
+	for _, seg := range segments {
+		if !strings.Contains(seg.Content, "docuguard:start") && !strings.Contains(seg.Content, "docuguard:bindCode") {
+			unbound = append(unbound, seg)
+		}
+	}
+
+	pairs, failed, err := e.twoStageMatch(ctx, symbols, unbound, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sections []SectionSuggestion
+	index := make(map[string]int)
+	for _, p := range pairs {
+		if p.Confidence < opts.MinConfidence {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", p.Segment.File, p.Segment.StartLine)
+		i, ok := index[key]
+		if !ok {
+			i = len(sections)
+			index[key] = i
+			sections = append(sections, SectionSuggestion{Segment: p.Segment})
+		}
+		sections[i].Suggestions = append(sections[i].Suggestions, BindingSuggestion{
+			Symbol:     p.Symbol,
+			Confidence: p.Confidence,
+			Reason:     p.Reason,
+		})
+	}
+
+	for i := range sections {
+		s := sections[i].Suggestions
+		sort.SliceStable(s, func(a, b int) bool { return s[a].Confidence > s[b].Confidence })
+		if opts.MaxPerSection > 0 && len(s) > opts.MaxPerSection {
+			sections[i].Suggestions = s[:opts.MaxPerSection]
+		}
+	}
+	sort.SliceStable(sections, func(a, b int) bool {
+		if sections[a].Segment.File != sections[b].Segment.File {
+			return sections[a].Segment.File < sections[b].Segment.File
+		}
+		return sections[a].Segment.StartLine < sections[b].Segment.StartLine
+	})
+	return sections, failed, nil
+}
+
+// BindEdits returns the edits that insert the suggested bindings around
+// their sections. Sections that cannot be edited are returned as errors.
+func BindEdits(sections []SectionSuggestion) ([]*fix.Edit, []error) {
+	var edits []*fix.Edit
+	var failed []error
+	for _, s := range sections {
+		var directives, names []string
+		for _, b := range s.Suggestions {
+			directives = append(directives, b.Directive())
+			names = append(names, b.Symbol.Name)
+		}
+		edit, err := fix.BindEdit(s.Segment, directives)
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s:%d (%s): %w", s.Segment.File, s.Segment.StartLine, s.Segment.Heading, err))
+			continue
+		}
+		edit.Symbol, edit.Reason = strings.Join(names, ", "), "suggested binding"
+		edits = append(edits, edit)
+	}
+	return edits, failed
+}
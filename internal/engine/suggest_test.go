@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/config"
+	"github.com/blueberrycongee/docuguard/internal/llm"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestPREngineSuggestBindings(t *testing.T) {
+	shipping := types.DocSegment{File: "docs/shop.md", StartLine: 3, Heading: "Shipping",
+		Content: "## Shipping\n\nCalculateShipping charges $10 below $100."}
+	bound := types.DocSegment{File: "docs/shop.md", StartLine: 9, Heading: "Tax",
+		Content: "## Tax\n\n<!-- docuguard:start -->\n<!-- docuguard:bindCode path=\"shop.go\" func=\"CalculateTax\" -->\nCalculateTax adds 8%.\n<!-- docuguard:end -->"}
+	symbols := []types.ChangedSymbol{
+		{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop/shipping.go"},
+		{Name: "CalculateTax", Type: types.BindingFunc, File: "shop/tax.go"},
+		{Name: "Shipment", Type: types.BindingStruct, File: "shop/shipment.go"},
+	}
+
+	e := &PREngine{
+		cfg:       &config.Config{},
+		llmClient: &llm.MockClient{RelevantIndices: []int{0}},
+	}
+	sections, failed, err := e.SuggestBindings(context.Background(), symbols, []types.DocSegment{bound, shipping},
+		SuggestOptions{MinConfidence: 0.6})
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+
+	require.Len(t, sections, 1, "sections with a binding block are skipped")
+	assert.Equal(t, "Shipping", sections[0].Segment.Heading)
+	require.Len(t, sections[0].Suggestions, 1, "weak keyword matches are dropped")
+	s := sections[0].Suggestions[0]
+	assert.Equal(t, "CalculateShipping", s.Symbol.Name)
+	assert.Equal(t, 1.0, s.Confidence)
+	assert.Contains(t, s.Reason, "LLM confirmed relevant (exact name match")
+	assert.Equal(t, `<!-- docuguard:bindCode path="shop/shipping.go" func="CalculateShipping" -->`, s.Directive())
+}
+
+func TestPREngineSuggestBindings_RelevanceFails(t *testing.T) {
+	shipping := types.DocSegment{File: "docs/shop.md", StartLine: 3, Heading: "Shipping",
+		Content: "## Shipping\n\nCalculateShipping charges $10 below $100."}
+	symbols := []types.ChangedSymbol{{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop/shipping.go"}}
+
+	e := &PREngine{
+		cfg:       &config.Config{},
+		llmClient: &llm.MockClient{Err: errors.New("connection refused")},
+	}
+	sections, failed, err := e.SuggestBindings(context.Background(), symbols, []types.DocSegment{shipping}, SuggestOptions{})
+	require.NoError(t, err)
+
+	assert.Empty(t, sections, "unconfirmed candidates are not suggested")
+	require.Len(t, failed, 1)
+	var relErr *RelevanceError
+	require.ErrorAs(t, failed[0], &relErr)
+	assert.Equal(t, "CalculateShipping", relErr.Symbol.Name)
+	assert.Equal(t, 1, relErr.Candidates)
+	assert.ErrorContains(t, failed[0], "connection refused")
+}
@@ -0,0 +1,63 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+const (
+	blockStart = "<!-- docuguard:start -->"
+	blockEnd   = "<!-- docuguard:end -->"
+)
+
+// BindEdit wraps the body of a Markdown section, below its heading, in a
+// docuguard:start/end block holding the given bindCode directives. Sections
+// that already hold docuguard directives other than ignore are left alone.
+func BindEdit(seg types.DocSegment, directives []string) (*Edit, error) {
+	if len(directives) == 0 {
+		return nil, fmt.Errorf("no bindings to insert")
+	}
+
+	lines, _, err := readLines(seg.File)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := trimBlank(lines, seg.StartLine, seg.EndLine)
+	if err != nil {
+		return nil, err
+	}
+	original := lines[start-1 : end]
+	if strings.TrimSpace(strings.Join(original, "\n")) != seg.Content {
+		return nil, ErrStale
+	}
+	if len(original) < 2 {
+		return nil, fmt.Errorf("section has no body to bind")
+	}
+	for _, line := range original {
+		if strings.Contains(line, "docuguard:") && !strings.Contains(line, "docuguard:ignore") {
+			return nil, fmt.Errorf("section already holds docuguard directives")
+		}
+	}
+
+	// Keep a blank line between the heading and the block, if there was one.
+	body := original[1:]
+	replacement := []string{original[0]}
+	for len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+		replacement = append(replacement, body[0])
+		body = body[1:]
+	}
+	replacement = append(replacement, blockStart)
+	replacement = append(replacement, directives...)
+	replacement = append(replacement, body...)
+	replacement = append(replacement, blockEnd)
+
+	return &Edit{
+		File:        seg.File,
+		StartLine:   start,
+		EndLine:     end,
+		Original:    append([]string(nil), original...),
+		Replacement: replacement,
+	}, nil
+}
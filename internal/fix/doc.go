@@ -0,0 +1,8 @@
+// Package fix turns rewritten documentation into validated edits.
+//
+// An Edit replaces the line range of one inconsistent Markdown section or
+// binding block. Rewrites are checked against the file before an edit is
+// created, so a rewrite that drops the heading, spills into a neighbouring
+// section or removes docuguard directives is rejected. Edits can be rendered
+// as a unified diff or applied in place.
+package fix
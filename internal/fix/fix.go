@@ -0,0 +1,173 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var (
+	// ErrNoChange is returned when a rewrite leaves the documentation as is.
+	ErrNoChange = errors.New("rewrite does not change the documentation")
+	// ErrStale is returned when the file no longer holds the checked content.
+	ErrStale = errors.New("documentation changed since it was checked")
+
+	headingRegex = regexp.MustCompile(`^(#{1,6})\s+`)
+	fenceRegex   = regexp.MustCompile("^```")
+)
+
+// Edit replaces lines StartLine..EndLine (1-based, inclusive) of File.
+type Edit struct {
+	File        string   `json:"file"`
+	StartLine   int      `json:"start_line"`
+	EndLine     int      `json:"end_line"`
+	Original    []string `json:"original"`
+	Replacement []string `json:"replacement"`
+	// Symbol and Reason describe the inconsistency the edit fixes.
+	Symbol string `json:"symbol"`
+	Reason string `json:"reason"`
+}
+
+// SegmentEdit validates a rewrite of a Markdown section. The rewrite must
+// keep the heading line and may not start another section of the same or a
+// higher level, so it cannot spill over into neighbouring sections.
+func SegmentEdit(seg types.DocSegment, rewritten string) (*Edit, error) {
+	lines, _, err := readLines(seg.File)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := trimBlank(lines, seg.StartLine, seg.EndLine)
+	if err != nil {
+		return nil, err
+	}
+	original := lines[start-1 : end]
+	if strings.TrimSpace(strings.Join(original, "\n")) != seg.Content {
+		return nil, ErrStale
+	}
+
+	replacement := splitBlock(rewritten)
+	if len(replacement) == 0 || replacement[0] != original[0] {
+		return nil, fmt.Errorf("rewrite changes the section heading")
+	}
+	inFence := false
+	for _, line := range replacement[1:] {
+		if fenceRegex.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if m := headingRegex.FindStringSubmatch(line); m != nil && !inFence && len(m[1]) <= seg.Level {
+			return nil, fmt.Errorf("rewrite adds a heading outside the section: %s", line)
+		}
+	}
+
+	return newEdit(seg.File, start, end, original, replacement)
+}
+
+// BindingEdit validates a rewrite of the documentation inside a
+// docuguard:start/end block, below its bindCode directives. Blocks that hold
+// other docuguard directives are left alone, since a rewrite could drop them.
+func BindingEdit(b types.Binding, rewritten string) (*Edit, error) {
+	if b.DocEndLine == 0 {
+		return nil, fmt.Errorf("binding block has no docuguard:end")
+	}
+
+	lines, _, err := readLines(b.DocFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the other bindCode directives of a block bound to several symbols.
+	first := b.DocLine + 1
+	for first < b.DocEndLine && first <= len(lines) {
+		if directive, _, ok := parser.BindingDirective(lines[first-1]); !ok || directive != "bindCode" {
+			break
+		}
+		first++
+	}
+
+	start, end, err := trimBlank(lines, first, b.DocEndLine-1)
+	if err != nil {
+		return nil, err
+	}
+	original := lines[start-1 : end]
+	for _, line := range original {
+		if strings.Contains(line, "docuguard:") {
+			return nil, fmt.Errorf("binding block contains docuguard directives")
+		}
+	}
+	if strings.TrimSpace(strings.Join(original, "\n")) != b.DocContent {
+		return nil, ErrStale
+	}
+
+	return newEdit(b.DocFile, start, end, original, splitBlock(rewritten))
+}
+
+func newEdit(file string, start, end int, original, replacement []string) (*Edit, error) {
+	if len(replacement) == 0 {
+		return nil, fmt.Errorf("empty rewrite")
+	}
+	for _, line := range replacement {
+		if strings.Contains(line, "docuguard:") {
+			return nil, fmt.Errorf("rewrite contains docuguard directives")
+		}
+	}
+	if strings.Join(original, "\n") == strings.Join(replacement, "\n") {
+		return nil, ErrNoChange
+	}
+
+	return &Edit{
+		File:        file,
+		StartLine:   start,
+		EndLine:     end,
+		Original:    append([]string(nil), original...),
+		Replacement: replacement,
+	}, nil
+}
+
+// trimBlank narrows a 1-based line range to exclude leading and trailing blank lines.
+func trimBlank(lines []string, start, end int) (int, int, error) {
+	if start < 1 || end > len(lines) || start > end {
+		return 0, 0, fmt.Errorf("line range %d-%d is outside the file: %w", start, end, ErrStale)
+	}
+	for start <= end && strings.TrimSpace(lines[start-1]) == "" {
+		start++
+	}
+	for end >= start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("documentation block is empty")
+	}
+	return start, end, nil
+}
+
+// splitBlock splits rewritten text into lines without surrounding blank lines.
+func splitBlock(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimRight(text, " \t\n")
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	return lines
+}
+
+// readLines returns the lines of a file and whether it ends with a newline.
+func readLines(path string) ([]string, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if text == "" {
+		return nil, true, nil
+	}
+	trailing := strings.HasSuffix(text, "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), trailing, nil
+}
@@ -0,0 +1,186 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+const shopDoc = `# Shop
+
+## Shipping
+
+Orders over $100 ship free.
+Standard shipping costs $10.
+
+## Returns
+
+Returns are accepted for 30 days.
+`
+
+func writeDoc(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "README.md")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func shippingSegment(path string) types.DocSegment {
+	return types.DocSegment{
+		File: path, StartLine: 3, EndLine: 7, Heading: "Shipping", Level: 2,
+		Content: "## Shipping\n\nOrders over $100 ship free.\nStandard shipping costs $10.",
+	}
+}
+
+func TestSegmentEdit(t *testing.T) {
+	path := writeDoc(t, shopDoc)
+
+	edit, err := SegmentEdit(shippingSegment(path), "## Shipping\n\nOrders over $500 ship free.\nStandard shipping costs $10.\n")
+	require.NoError(t, err)
+	assert.Equal(t, 3, edit.StartLine)
+	assert.Equal(t, 6, edit.EndLine, "trailing blank lines stay outside the edit")
+
+	patch, err := UnifiedDiff([]*Edit{edit})
+	require.NoError(t, err)
+	name := filepath.ToSlash(filepath.Clean(path))
+	assert.Equal(t, "--- a/"+name+"\n+++ b/"+name+"\n"+
+		"@@ -2,7 +2,7 @@\n"+
+		" \n"+
+		" ## Shipping\n"+
+		" \n"+
+		"-Orders over $100 ship free.\n"+
+		"+Orders over $500 ship free.\n"+
+		" Standard shipping costs $10.\n"+
+		" \n"+
+		" ## Returns\n", patch)
+
+	require.NoError(t, Apply([]*Edit{edit}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Orders over $500 ship free.\nStandard shipping costs $10.\n\n## Returns")
+}
+
+func TestApplyStaleFileWritesNothing(t *testing.T) {
+	first, second := writeDoc(t, shopDoc), writeDoc(t, shopDoc)
+	rewrite := "## Shipping\n\nOrders over $500 ship free.\nStandard shipping costs $10.\n"
+	firstEdit, err := SegmentEdit(shippingSegment(first), rewrite)
+	require.NoError(t, err)
+	secondEdit, err := SegmentEdit(shippingSegment(second), rewrite)
+	require.NoError(t, err)
+
+	stale := strings.Replace(shopDoc, "$100", "$200", 1)
+	require.NoError(t, os.WriteFile(second, []byte(stale), 0644))
+
+	require.Error(t, Apply([]*Edit{firstEdit, secondEdit}))
+	data, err := os.ReadFile(first)
+	require.NoError(t, err)
+	assert.Equal(t, shopDoc, string(data), "no file is written when one is stale")
+}
+
+func TestSegmentEditRejectsOutOfRange(t *testing.T) {
+	path := writeDoc(t, shopDoc)
+	seg := shippingSegment(path)
+
+	_, err := SegmentEdit(seg, "## Delivery\n\nOrders over $500 ship free.")
+	assert.ErrorContains(t, err, "heading")
+
+	_, err = SegmentEdit(seg, "## Shipping\n\nOrders over $500 ship free.\n\n## Returns\n\nNo returns.")
+	assert.ErrorContains(t, err, "outside the section")
+
+	_, err = SegmentEdit(seg, seg.Content)
+	assert.ErrorIs(t, err, ErrNoChange)
+
+	seg.Content = "## Shipping\n\nSomething else."
+	_, err = SegmentEdit(seg, "## Shipping\n\nNew text.")
+	assert.ErrorIs(t, err, ErrStale)
+}
+
+func TestBindingEdit(t *testing.T) {
+	doc := "<!-- docuguard:start -->\n" +
+		"<!-- docuguard:bindCode path=\"shop.go\" func=\"CalculateShipping\" -->\n" +
+		"\n" +
+		"Orders over $100 ship free.\n" +
+		"\n" +
+		"<!-- docuguard:end -->"
+	path := writeDoc(t, doc)
+	b := types.Binding{DocFile: path, DocLine: 2, DocEndLine: 6, DocContent: "Orders over $100 ship free."}
+
+	edit, err := BindingEdit(b, "Orders over $500 ship free.")
+	require.NoError(t, err)
+	assert.Equal(t, 4, edit.StartLine)
+	assert.Equal(t, 4, edit.EndLine)
+
+	_, err = BindingEdit(b, "Orders over $500 ship free.\n<!-- docuguard:end -->")
+	assert.ErrorContains(t, err, "directives")
+
+	require.NoError(t, Apply([]*Edit{edit}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "<!-- docuguard:start -->\n"+
+		"<!-- docuguard:bindCode path=\"shop.go\" func=\"CalculateShipping\" -->\n"+
+		"\n"+
+		"Orders over $500 ship free.\n"+
+		"\n"+
+		"<!-- docuguard:end -->", string(data), "a missing final newline is preserved")
+}
+
+func TestUnifiedDiffMultipleHunks(t *testing.T) {
+	var content string
+	for i := 1; i <= 20; i++ {
+		content += "line\n"
+	}
+	path := writeDoc(t, content)
+
+	edits := []*Edit{
+		{File: path, StartLine: 15, EndLine: 15, Original: []string{"line"}, Replacement: []string{"fifteen"}},
+		{File: path, StartLine: 2, EndLine: 2, Original: []string{"line"}, Replacement: []string{"two", "two and a half"}},
+	}
+	patch, err := UnifiedDiff(edits)
+	require.NoError(t, err)
+	assert.Contains(t, patch, "@@ -1,5 +1,6 @@\n")
+	assert.Contains(t, patch, "@@ -12,7 +13,7 @@\n", "later hunks account for lines added earlier")
+
+	edits = append(edits, &Edit{File: path, StartLine: 14, EndLine: 16, Original: []string{"line", "line", "line"}, Replacement: []string{"x"}})
+	_, err = UnifiedDiff(edits)
+	assert.ErrorContains(t, err, "overlap")
+}
+
+func TestBindEdit(t *testing.T) {
+	path := writeDoc(t, shopDoc)
+	directive := `<!-- docuguard:bindCode path="shop/shipping.go" func="CalculateShipping" -->`
+
+	edit, err := BindEdit(shippingSegment(path), []string{directive})
+	require.NoError(t, err)
+	assert.Equal(t, 3, edit.StartLine)
+	assert.Equal(t, 6, edit.EndLine)
+	assert.Equal(t, []string{
+		"## Shipping",
+		"",
+		"<!-- docuguard:start -->",
+		directive,
+		"Orders over $100 ship free.",
+		"Standard shipping costs $10.",
+		"<!-- docuguard:end -->",
+	}, edit.Replacement)
+
+	require.NoError(t, Apply([]*Edit{edit}))
+	bindings, err := parser.ExtractBindings(path)
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "CalculateShipping", bindings[0].CodeSymbol)
+	assert.Equal(t, "Orders over $100 ship free.\nStandard shipping costs $10.", bindings[0].DocContent)
+
+	// The section now holds a binding block and is not wrapped again.
+	seg := shippingSegment(path)
+	seg.EndLine = 10
+	seg.Content = "## Shipping\n\n" + strings.Join(edit.Replacement[2:], "\n")
+	_, err = BindEdit(seg, []string{directive})
+	assert.ErrorContains(t, err, "already holds docuguard directives")
+}
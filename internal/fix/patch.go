@@ -0,0 +1,229 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+// change is the part of an edit that differs from the file, as a 0-based
+// half-open range of old lines and the lines replacing it.
+type change struct {
+	oldStart, oldEnd int
+	newLines         []string
+}
+
+// core strips the lines an edit leaves unchanged at either end.
+func (e *Edit) core() change {
+	orig, repl := e.Original, e.Replacement
+	prefix := 0
+	for prefix < len(orig) && prefix < len(repl) && orig[prefix] == repl[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(orig)-prefix && suffix < len(repl)-prefix &&
+		orig[len(orig)-1-suffix] == repl[len(repl)-1-suffix] {
+		suffix++
+	}
+	return change{
+		oldStart: e.StartLine - 1 + prefix,
+		oldEnd:   e.EndLine - suffix,
+		newLines: repl[prefix : len(repl)-suffix],
+	}
+}
+
+// groupByFile orders edits by file, in order of first appearance, and by
+// line within a file. Overlapping edits are rejected.
+func groupByFile(edits []*Edit) ([]string, map[string][]*Edit, error) {
+	var files []string
+	byFile := make(map[string][]*Edit)
+	for _, e := range edits {
+		if _, ok := byFile[e.File]; !ok {
+			files = append(files, e.File)
+		}
+		byFile[e.File] = append(byFile[e.File], e)
+	}
+
+	for _, file := range files {
+		fileEdits := byFile[file]
+		sort.SliceStable(fileEdits, func(i, j int) bool { return fileEdits[i].StartLine < fileEdits[j].StartLine })
+		for i := 1; i < len(fileEdits); i++ {
+			if fileEdits[i].StartLine <= fileEdits[i-1].EndLine {
+				return nil, nil, fmt.Errorf("%s: edits at lines %d and %d overlap",
+					file, fileEdits[i-1].StartLine, fileEdits[i].StartLine)
+			}
+		}
+	}
+	return files, byFile, nil
+}
+
+// checkOriginal verifies that the file still holds the lines an edit replaces.
+func checkOriginal(lines []string, e *Edit) error {
+	if e.EndLine > len(lines) {
+		return fmt.Errorf("%s:%d: %w", e.File, e.StartLine, ErrStale)
+	}
+	for i, line := range e.Original {
+		if lines[e.StartLine-1+i] != line {
+			return fmt.Errorf("%s:%d: %w", e.File, e.StartLine, ErrStale)
+		}
+	}
+	return nil
+}
+
+// UnifiedDiff renders the edits as a unified diff that 'git apply' accepts.
+func UnifiedDiff(edits []*Edit) (string, error) {
+	files, byFile, err := groupByFile(edits)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, file := range files {
+		lines, trailing, err := readLines(file)
+		if err != nil {
+			return "", err
+		}
+
+		var changes []change
+		for _, e := range byFile[file] {
+			if err := checkOriginal(lines, e); err != nil {
+				return "", err
+			}
+			changes = append(changes, e.core())
+		}
+
+		name := filepath.ToSlash(filepath.Clean(file))
+		fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", name, name)
+		writeHunks(&sb, lines, trailing, changes)
+	}
+	return sb.String(), nil
+}
+
+// writeHunks writes the changes of one file, merging changes whose context overlaps.
+func writeHunks(sb *strings.Builder, lines []string, trailing bool, changes []change) {
+	delta := 0
+	for i := 0; i < len(changes); {
+		j := i + 1
+		for j < len(changes) && changes[j].oldStart-changes[j-1].oldEnd <= 2*contextLines {
+			j++
+		}
+		group := changes[i:j]
+
+		start := max(group[0].oldStart-contextLines, 0)
+		end := min(group[len(group)-1].oldEnd+contextLines, len(lines))
+
+		var body strings.Builder
+		noNewline := func(lastOld int) {
+			if !trailing && lastOld == len(lines) {
+				body.WriteString("\\ No newline at end of file\n")
+			}
+		}
+
+		oldCount, newCount := end-start, end-start
+		pos := start
+		for _, c := range group {
+			for ; pos < c.oldStart; pos++ {
+				body.WriteString(" " + lines[pos] + "\n")
+			}
+			for ; pos < c.oldEnd; pos++ {
+				body.WriteString("-" + lines[pos] + "\n")
+			}
+			noNewline(c.oldEnd)
+			for _, line := range c.newLines {
+				body.WriteString("+" + line + "\n")
+			}
+			if len(c.newLines) > 0 {
+				noNewline(c.oldEnd)
+			}
+			newCount += len(c.newLines) - (c.oldEnd - c.oldStart)
+		}
+		for ; pos < end; pos++ {
+			body.WriteString(" " + lines[pos] + "\n")
+		}
+		if end > group[len(group)-1].oldEnd {
+			noNewline(end)
+		}
+
+		fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(start, oldCount), hunkRange(start+delta, newCount))
+		sb.WriteString(body.String())
+
+		delta += newCount - oldCount
+		i = j
+	}
+}
+
+// hunkRange formats a 0-based start and a line count for a hunk header.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// Apply writes the edits to their files. Every file is checked before any
+// is written, so if the content of one no longer matches its edits, no file
+// is changed.
+func Apply(edits []*Edit) error {
+	files, byFile, err := groupByFile(edits)
+	if err != nil {
+		return err
+	}
+
+	type update struct {
+		file string
+		text string
+		perm os.FileMode
+	}
+	updates := make([]update, 0, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		lines, trailing, err := readLines(file)
+		if err != nil {
+			return err
+		}
+
+		fileEdits := byFile[file]
+		for _, e := range fileEdits {
+			if err := checkOriginal(lines, e); err != nil {
+				return err
+			}
+		}
+
+		// Apply bottom-up so earlier line numbers stay valid.
+		for i := len(fileEdits) - 1; i >= 0; i-- {
+			e := fileEdits[i]
+			updated := append([]string(nil), lines[:e.StartLine-1]...)
+			updated = append(updated, e.Replacement...)
+			lines = append(updated, lines[e.EndLine:]...)
+		}
+
+		newline := "\n"
+		if strings.Contains(string(data), "\r\n") {
+			newline = "\r\n"
+		}
+		text := strings.Join(lines, newline)
+		if trailing {
+			text += newline
+		}
+		updates = append(updates, update{file: file, text: text, perm: info.Mode().Perm()})
+	}
+
+	for _, u := range updates {
+		if err := os.WriteFile(u.file, []byte(u.text), u.perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
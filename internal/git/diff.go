@@ -22,7 +22,8 @@ var (
 	deletedFileRegex = regexp.MustCompile(`^deleted file mode`)
 
 	// Go symbol patterns for extracting from diff lines
-	funcDeclRegex = regexp.MustCompile(`func\s+(?:\([^)]+\)\s+)?(\w+)\s*\(`)
+	// funcDeclRegex captures the receiver base type (if any) and the function name.
+	funcDeclRegex = regexp.MustCompile(`func\s+(?:\(\s*(?:\w+\s+)?\*?\s*(\w+)(?:\[[^\]]*\])?\s*\)\s*)?(\w+)\s*[\[(]`)
 	constVarRegex = regexp.MustCompile(`^\s*(\w+)\s*=`)
 	typeRegex     = regexp.MustCompile(`type\s+(\w+)\s+`)
 )
@@ -42,7 +43,14 @@ func GetDiff(baseBranch string) (string, error) {
 }
 
 // GetDiffUncommitted returns the diff of uncommitted changes.
+// Staged and unstaged changes are combined into a single diff against HEAD,
+// so line numbers refer to HEAD and the working tree.
 func GetDiffUncommitted() (string, error) {
+	if output, err := exec.Command("git", "diff", "HEAD").Output(); err == nil {
+		return string(output), nil
+	}
+
+	// No HEAD yet (fresh repository): fall back to concatenating both diffs.
 	cmd := exec.Command("git", "diff", "--cached")
 	staged, err := cmd.Output()
 	if err != nil {
@@ -59,19 +67,20 @@ func GetDiffUncommitted() (string, error) {
 }
 
 // ParseDiffWithContent parses diff and extracts changed lines content.
+// Line numbers of added and removed lines are recorded alongside their content.
 func ParseDiffWithContent(diffContent string) ([]types.FileDiff, error) {
 	var fileDiffs []types.FileDiff
 	var currentDiff *types.FileDiff
-	var addedLines, removedLines []string
+	inHunk := false
+	oldLine, newLine := 0, 0
 
 	scanner := bufio.NewScanner(strings.NewReader(diffContent))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		if matches := diffHeaderRegex.FindStringSubmatch(line); matches != nil {
 			if currentDiff != nil {
-				currentDiff.AddedLines = addedLines
-				currentDiff.RemovedLines = removedLines
 				fileDiffs = append(fileDiffs, *currentDiff)
 			}
 			currentDiff = &types.FileDiff{
@@ -79,8 +88,7 @@ func ParseDiffWithContent(diffContent string) ([]types.FileDiff, error) {
 				NewPath:    matches[2],
 				ChangeType: types.ChangeModified,
 			}
-			addedLines = nil
-			removedLines = nil
+			inHunk = false
 			continue
 		}
 
@@ -88,23 +96,6 @@ func ParseDiffWithContent(diffContent string) ([]types.FileDiff, error) {
 			continue
 		}
 
-		if newFileRegex.MatchString(line) {
-			currentDiff.ChangeType = types.ChangeAdded
-			continue
-		}
-
-		if deletedFileRegex.MatchString(line) {
-			currentDiff.ChangeType = types.ChangeDeleted
-			continue
-		}
-
-		// Capture added and removed lines
-		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-			addedLines = append(addedLines, strings.TrimPrefix(line, "+"))
-		} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
-			removedLines = append(removedLines, strings.TrimPrefix(line, "-"))
-		}
-
 		if matches := hunkHeaderRegex.FindStringSubmatch(line); matches != nil {
 			lc := types.LineChange{}
 			lc.OldStart, _ = strconv.Atoi(matches[1])
@@ -120,12 +111,40 @@ func ParseDiffWithContent(diffContent string) ([]types.FileDiff, error) {
 				lc.NewCount = 1
 			}
 			currentDiff.ChangedLines = append(currentDiff.ChangedLines, lc)
+			inHunk = true
+			oldLine, newLine = lc.OldStart, lc.NewStart
+			continue
+		}
+
+		// File header lines (mode, index, ---/+++) only appear before the first hunk.
+		if !inHunk {
+			if newFileRegex.MatchString(line) {
+				currentDiff.ChangeType = types.ChangeAdded
+			} else if deletedFileRegex.MatchString(line) {
+				currentDiff.ChangeType = types.ChangeDeleted
+			}
+			continue
+		}
+
+		// Capture added and removed lines
+		switch {
+		case strings.HasPrefix(line, "+"):
+			currentDiff.AddedLines = append(currentDiff.AddedLines, line[1:])
+			currentDiff.AddedLineNumbers = append(currentDiff.AddedLineNumbers, newLine)
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			currentDiff.RemovedLines = append(currentDiff.RemovedLines, line[1:])
+			currentDiff.RemovedLineNumbers = append(currentDiff.RemovedLineNumbers, oldLine)
+			oldLine++
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file"
+		default:
+			oldLine++
+			newLine++
 		}
 	}
 
 	if currentDiff != nil {
-		currentDiff.AddedLines = addedLines
-		currentDiff.RemovedLines = removedLines
 		fileDiffs = append(fileDiffs, *currentDiff)
 	}
 
@@ -144,9 +163,12 @@ func ExtractSymbolsFromDiffLines(lines []string) []string {
 			continue
 		}
 
-		// Extract function names
+		// Extract function names, qualifying methods as Type.Method
 		if matches := funcDeclRegex.FindStringSubmatch(line); matches != nil {
-			name := matches[1]
+			name := matches[2]
+			if matches[1] != "" {
+				name = matches[1] + "." + name
+			}
 			if !seen[name] {
 				seen[name] = true
 				symbols = append(symbols, name)
@@ -217,3 +239,12 @@ func GetCurrentBranch() (string, error) {
 	}
 	return strings.TrimSpace(string(output)), nil
 }
+
+// Add stages the given paths.
+func Add(paths ...string) error {
+	args := append([]string{"add", "--"}, paths...)
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
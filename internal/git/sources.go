@@ -0,0 +1,78 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SourceLoader loads the contents of a file on either side of a diff.
+type SourceLoader interface {
+	// Old returns the file content before the change.
+	Old(path string) ([]byte, error)
+	// New returns the file content after the change.
+	New(path string) ([]byte, error)
+}
+
+// GitSources loads old file versions from a git revision and new versions
+// from the working tree. Paths are relative to the repository root, as in
+// diff headers.
+type GitSources struct {
+	baseRef string
+
+	once    sync.Once
+	rev     string
+	rootDir string
+}
+
+// NewGitSources creates a SourceLoader comparing baseRef with the working tree.
+// The old side is read from the merge base of baseRef and HEAD when one exists,
+// matching what `git diff base...HEAD` compares against.
+func NewGitSources(baseRef string) *GitSources {
+	return &GitSources{baseRef: baseRef}
+}
+
+// Old returns the content of path at the base revision.
+func (s *GitSources) Old(path string) ([]byte, error) {
+	s.resolve()
+	if s.rev == "" {
+		return nil, fmt.Errorf("cannot resolve base revision %q", s.baseRef)
+	}
+
+	output, err := exec.Command("git", "-C", s.rootDir, "show", s.rev+":"+path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, s.rev, err)
+	}
+	return output, nil
+}
+
+// New returns the content of path in the working tree.
+func (s *GitSources) New(path string) ([]byte, error) {
+	s.resolve()
+	return os.ReadFile(filepath.Join(s.rootDir, path))
+}
+
+// resolve determines the repository root and the base revision once.
+// Both the plain ref and its origin/ counterpart are tried, since CI
+// checkouts often only have remote-tracking branches.
+func (s *GitSources) resolve() {
+	s.once.Do(func() {
+		if output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output(); err == nil {
+			s.rootDir = strings.TrimSpace(string(output))
+		}
+
+		for _, ref := range []string{s.baseRef, "origin/" + s.baseRef} {
+			if output, err := exec.Command("git", "merge-base", ref, "HEAD").Output(); err == nil {
+				s.rev = strings.TrimSpace(string(output))
+				return
+			}
+			if output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Output(); err == nil {
+				s.rev = strings.TrimSpace(string(output))
+				return
+			}
+		}
+	})
+}
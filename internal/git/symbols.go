@@ -1,22 +1,32 @@
 package git
 
 import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"strings"
 
+	goparser "github.com/blueberrycongee/docuguard/internal/parser"
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
 // SymbolExtractor extracts changed symbols from git diffs.
-type SymbolExtractor struct{}
+type SymbolExtractor struct {
+	sources SourceLoader
+}
 
 // NewSymbolExtractor creates a new SymbolExtractor.
-func NewSymbolExtractor() *SymbolExtractor {
-	return &SymbolExtractor{}
+// With a SourceLoader, changed lines are mapped onto the declarations that
+// enclose them in the parsed old and new files. Without one, or when a file
+// cannot be loaded or parsed, symbols are guessed from the diff lines alone.
+func NewSymbolExtractor(sources SourceLoader) *SymbolExtractor {
+	return &SymbolExtractor{sources: sources}
 }
 
 // ExtractChangedSymbols extracts changed Go symbols from diff content.
 func (e *SymbolExtractor) ExtractChangedSymbols(diffContent string) ([]types.ChangedSymbol, error) {
-	// Use the new method that parses diff content directly
 	fileDiffs, err := ParseDiffWithContent(diffContent)
 	if err != nil {
 		return nil, err
@@ -26,42 +36,336 @@ func (e *SymbolExtractor) ExtractChangedSymbols(diffContent string) ([]types.Cha
 
 	var symbols []types.ChangedSymbol
 	for _, fd := range goFiles {
-		// Extract symbols from added lines (new/modified code)
-		addedSymbols := ExtractSymbolsFromDiffLines(fd.AddedLines)
-		for _, name := range addedSymbols {
-			sym := types.ChangedSymbol{
-				File:       fd.NewPath,
-				Name:       name,
-				Type:       guessSymbolType(name, fd.AddedLines),
-				ChangeType: fd.ChangeType,
+		if e.sources != nil {
+			if fileSymbols, err := e.extractFromSources(fd); err == nil {
+				symbols = append(symbols, fileSymbols...)
+				continue
 			}
-			// Extract code directly from diff lines (not from local file)
-			sym.NewCode = extractSymbolCodeFromLines(name, fd.AddedLines)
-			sym.OldCode = extractSymbolCodeFromLines(name, fd.RemovedLines)
-			symbols = append(symbols, sym)
 		}
+		symbols = append(symbols, extractFromDiffLines(fd)...)
+	}
+
+	return symbols, nil
+}
+
+// declInfo describes a top-level declaration in one version of a file.
+type declInfo struct {
+	name      string
+	kind      types.BindingType
+	startLine int
+	endLine   int
+	code      string
+	// ignore is the //docuguard:ignore directive on the declaration.
+	ignore *types.Suppression
+}
+
+// DeclaredSymbols returns the exported top-level declarations of a Go source
+// file as unchanged symbols, for auditing code that no diff touches.
+// Methods are exported when both the method and its receiver type are.
+func DeclaredSymbols(path string, src []byte) ([]types.ChangedSymbol, error) {
+	decls, err := parseDecls(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []types.ChangedSymbol
+	for _, d := range decls {
+		recv, bare := goparser.SplitQualifiedName(d.name)
+		if !ast.IsExported(bare) || (recv != "" && !ast.IsExported(recv)) {
+			continue
+		}
+		symbols = append(symbols, types.ChangedSymbol{
+			File:        path,
+			Name:        d.name,
+			Type:        d.kind,
+			NewCode:     d.code,
+			ChangeType:  types.ChangeNone,
+			StartLine:   d.startLine,
+			EndLine:     d.endLine,
+			Suppression: d.ignore,
+		})
+	}
+	return symbols, nil
+}
+
+// extractFromSources parses both versions of a file and reports every
+// declaration whose line range contains an added or removed line.
+func (e *SymbolExtractor) extractFromSources(fd types.FileDiff) ([]types.ChangedSymbol, error) {
+	var oldDecls, newDecls []declInfo
+
+	if fd.ChangeType != types.ChangeAdded {
+		src, err := e.sources.Old(fd.OldPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyLines(src, fd.RemovedLineNumbers, fd.RemovedLines); err != nil {
+			return nil, err
+		}
+		if oldDecls, err = parseDecls(fd.OldPath, src); err != nil {
+			return nil, err
+		}
+	}
+
+	if fd.ChangeType != types.ChangeDeleted {
+		src, err := e.sources.New(fd.NewPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyLines(src, fd.AddedLineNumbers, fd.AddedLines); err != nil {
+			return nil, err
+		}
+		if newDecls, err = parseDecls(fd.NewPath, src); err != nil {
+			return nil, err
+		}
+	}
+
+	oldByName := make(map[string]declInfo, len(oldDecls))
+	for _, d := range oldDecls {
+		oldByName[d.name] = d
+	}
+	newByName := make(map[string]bool, len(newDecls))
+
+	var symbols []types.ChangedSymbol
+	for _, d := range newDecls {
+		newByName[d.name] = true
+		old, existed := oldByName[d.name]
+
+		if !touches(d, fd.AddedLineNumbers) && !(existed && touches(old, fd.RemovedLineNumbers)) {
+			continue
+		}
+
+		sym := types.ChangedSymbol{
+			File:        fd.NewPath,
+			Name:        d.name,
+			Type:        d.kind,
+			NewCode:     d.code,
+			ChangeType:  types.ChangeAdded,
+			StartLine:   d.startLine,
+			EndLine:     d.endLine,
+			Suppression: d.ignore,
+		}
+		if existed {
+			sym.OldCode = old.code
+			sym.ChangeType = types.ChangeModified
+		}
+		symbols = append(symbols, sym)
+	}
+
+	for _, d := range oldDecls {
+		if newByName[d.name] || !touches(d, fd.RemovedLineNumbers) {
+			continue
+		}
+		symbols = append(symbols, types.ChangedSymbol{
+			File:        fd.OldPath,
+			Name:        d.name,
+			Type:        d.kind,
+			OldCode:     d.code,
+			ChangeType:  types.ChangeDeleted,
+			StartLine:   d.startLine,
+			EndLine:     d.endLine,
+			Suppression: d.ignore,
+		})
+	}
+
+	return symbols, nil
+}
+
+// verifyLines checks that the diff lines match the loaded source, so a
+// working tree that is not at the diff's head revision is never misread.
+func verifyLines(src []byte, lineNumbers []int, lines []string) error {
+	fileLines := strings.Split(string(src), "\n")
+	for i, n := range lineNumbers {
+		if n < 1 || n > len(fileLines) || strings.TrimRight(fileLines[n-1], "\r") != strings.TrimRight(lines[i], "\r") {
+			return fmt.Errorf("source does not match diff at line %d", n)
+		}
+	}
+	return nil
+}
 
-		// For deleted files, extract from removed lines
-		if fd.ChangeType == types.ChangeDeleted {
-			removedSymbols := ExtractSymbolsFromDiffLines(fd.RemovedLines)
-			for _, name := range removedSymbols {
-				sym := types.ChangedSymbol{
-					File:       fd.OldPath,
-					Name:       name,
-					Type:       guessSymbolType(name, fd.RemovedLines),
-					ChangeType: types.ChangeDeleted,
-					OldCode:    extractSymbolCodeFromLines(name, fd.RemovedLines),
+// touches reports whether any of the line numbers falls inside the declaration.
+func touches(d declInfo, lineNumbers []int) bool {
+	for _, n := range lineNumbers {
+		if n >= d.startLine && n <= d.endLine {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDecls returns the top-level declarations of a Go source file.
+// Each spec of a grouped const/var/type declaration is reported separately.
+func parseDecls(path string, src []byte) ([]declInfo, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	snippet := func(from, to token.Pos) string {
+		return string(src[fset.Position(from).Offset:fset.Position(to).Offset])
+	}
+	line := func(pos token.Pos) int {
+		return fset.Position(pos).Line
+	}
+	ignore := func(name string, docs ...*ast.CommentGroup) *types.Suppression {
+		for _, doc := range docs {
+			if reason, ok := goparser.GoIgnore(doc); ok {
+				return &types.Suppression{Symbol: name, Reason: reason, File: path, Line: line(doc.Pos())}
+			}
+		}
+		return nil
+	}
+
+	var decls []declInfo
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			start := d.Pos()
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+			name := goparser.QualifiedName(d)
+			decls = append(decls, declInfo{
+				name:      name,
+				kind:      types.BindingFunc,
+				startLine: line(start),
+				endLine:   line(d.End()),
+				code:      formatCode(snippet(start, d.End())),
+				ignore:    ignore(name, d.Doc),
+			})
+
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+
+			for _, spec := range d.Specs {
+				var names []string
+				var kind types.BindingType
+				var specDoc, specComment *ast.CommentGroup
+
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = []string{s.Name.Name}
+					kind = typeKind(s)
+					specDoc, specComment = s.Doc, s.Comment
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						names = append(names, n.Name)
+					}
+					kind = types.BindingVar
+					if d.Tok == token.CONST {
+						kind = types.BindingConst
+					}
+					specDoc, specComment = s.Doc, s.Comment
+				}
+
+				var startLine, endLine int
+				var code string
+				if !d.Lparen.IsValid() {
+					// Ungrouped declaration: take it verbatim with its doc comment.
+					start := d.Pos()
+					if d.Doc != nil {
+						start = d.Doc.Pos()
+					}
+					end := d.End()
+					if specComment != nil && specComment.End() > end {
+						end = specComment.End()
+					}
+					startLine, endLine = line(start), line(end)
+					code = snippet(start, end)
+				} else {
+					// Grouped declaration: rebuild a standalone declaration for the spec.
+					start := spec.Pos()
+					if specDoc != nil {
+						start = specDoc.Pos()
+					}
+					end := spec.End()
+					if specComment != nil {
+						end = specComment.End()
+					}
+					startLine, endLine = line(start), line(end)
+
+					var sb strings.Builder
+					if specDoc != nil {
+						sb.WriteString(snippet(specDoc.Pos(), specDoc.End()))
+						sb.WriteString("\n")
+					}
+					sb.WriteString(d.Tok.String() + " " + snippet(spec.Pos(), end))
+					code = sb.String()
+				}
+
+				for _, name := range names {
+					if name == "_" {
+						continue
+					}
+					// A directive on a grouped declaration covers all its specs.
+					decls = append(decls, declInfo{
+						name:      name,
+						kind:      kind,
+						startLine: startLine,
+						endLine:   endLine,
+						code:      formatCode(code),
+						ignore:    ignore(name, specDoc, d.Doc),
+					})
 				}
-				symbols = append(symbols, sym)
 			}
 		}
 	}
 
-	return symbols, nil
+	return decls, nil
+}
+
+// formatCode gofmt-formats a declaration snippet, returning it unchanged if it does not parse on its own.
+func formatCode(code string) string {
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return code
+	}
+	return strings.TrimSpace(string(formatted))
+}
+
+// extractFromDiffLines guesses changed symbols from the raw diff lines.
+// It is the fallback when source files are unavailable.
+func extractFromDiffLines(fd types.FileDiff) []types.ChangedSymbol {
+	var symbols []types.ChangedSymbol
+
+	// Extract symbols from added lines (new/modified code)
+	addedSymbols := ExtractSymbolsFromDiffLines(fd.AddedLines)
+	for _, name := range addedSymbols {
+		sym := types.ChangedSymbol{
+			File:       fd.NewPath,
+			Name:       name,
+			Type:       guessSymbolType(name, fd.AddedLines),
+			ChangeType: fd.ChangeType,
+		}
+		// Extract code directly from diff lines (not from local file)
+		sym.NewCode = extractSymbolCodeFromLines(name, fd.AddedLines)
+		sym.OldCode = extractSymbolCodeFromLines(name, fd.RemovedLines)
+		symbols = append(symbols, sym)
+	}
+
+	// For deleted files, extract from removed lines
+	if fd.ChangeType == types.ChangeDeleted {
+		removedSymbols := ExtractSymbolsFromDiffLines(fd.RemovedLines)
+		for _, name := range removedSymbols {
+			sym := types.ChangedSymbol{
+				File:       fd.OldPath,
+				Name:       name,
+				Type:       guessSymbolType(name, fd.RemovedLines),
+				ChangeType: types.ChangeDeleted,
+				OldCode:    extractSymbolCodeFromLines(name, fd.RemovedLines),
+			}
+			symbols = append(symbols, sym)
+		}
+	}
+
+	return symbols
 }
 
 // extractSymbolCodeFromLines extracts the code for a symbol from diff lines.
 func extractSymbolCodeFromLines(symbolName string, lines []string) string {
+	_, symbolName = goparser.SplitQualifiedName(symbolName)
 	var codeLines []string
 	inSymbol := false
 
@@ -90,8 +394,23 @@ func extractSymbolCodeFromLines(symbolName string, lines []string) string {
 	return strings.Join(codeLines, "\n")
 }
 
+// typeKind classifies a type declaration by its underlying type.
+func typeKind(s *ast.TypeSpec) types.BindingType {
+	switch s.Type.(type) {
+	case *ast.StructType:
+		return types.BindingStruct
+	case *ast.InterfaceType:
+		return types.BindingInterface
+	default:
+		return types.BindingNamedType
+	}
+}
+
 // guessSymbolType tries to determine the symbol type from context.
 func guessSymbolType(name string, lines []string) types.BindingType {
+	if strings.Contains(name, ".") {
+		return types.BindingFunc
+	}
 	for _, line := range lines {
 		if funcDeclRegex.MatchString(line) && strings.Contains(line, name) {
 			return types.BindingFunc
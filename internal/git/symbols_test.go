@@ -0,0 +1,180 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// mapSources serves file versions from memory.
+type mapSources struct {
+	old map[string]string
+	new map[string]string
+}
+
+func (m mapSources) Old(path string) ([]byte, error) {
+	if src, ok := m.old[path]; ok {
+		return []byte(src), nil
+	}
+	return nil, fmt.Errorf("no old version of %s", path)
+}
+
+func (m mapSources) New(path string) ([]byte, error) {
+	if src, ok := m.new[path]; ok {
+		return []byte(src), nil
+	}
+	return nil, fmt.Errorf("no new version of %s", path)
+}
+
+const oldShipping = `package shop
+
+// CalculateShipping returns the shipping fee.
+func CalculateShipping(amount float64) float64 {
+	if amount >= 100 {
+		return 0
+	}
+	return 10
+}
+
+const (
+	// MaxItems limits the cart size.
+	MaxItems = 50
+	MinItems = 1
+)
+`
+
+const newShipping = `package shop
+
+// CalculateShipping returns the shipping fee.
+func CalculateShipping(amount float64) float64 {
+	if amount >= 500 {
+		return 0
+	}
+	return 10
+}
+
+const (
+	// MaxItems limits the cart size.
+	MaxItems = 50
+	MinItems = 2
+)
+`
+
+const shippingDiff = `diff --git a/shop/shipping.go b/shop/shipping.go
+index 1111111..2222222 100644
+--- a/shop/shipping.go
++++ b/shop/shipping.go
+@@ -2,7 +2,7 @@ package shop
+
+ // CalculateShipping returns the shipping fee.
+ func CalculateShipping(amount float64) float64 {
+-	if amount >= 100 {
++	if amount >= 500 {
+ 		return 0
+ 	}
+ 	return 10
+@@ -11,5 +11,5 @@ func CalculateShipping(amount float64) float64 {
+ const (
+ 	// MaxItems limits the cart size.
+ 	MaxItems = 50
+-	MinItems = 1
++	MinItems = 2
+ )
+`
+
+func TestExtractChangedSymbols_FromSources(t *testing.T) {
+	sources := mapSources{
+		old: map[string]string{"shop/shipping.go": oldShipping},
+		new: map[string]string{"shop/shipping.go": newShipping},
+	}
+
+	symbols, err := NewSymbolExtractor(sources).ExtractChangedSymbols(shippingDiff)
+	require.NoError(t, err)
+	require.Len(t, symbols, 2)
+
+	fn := symbols[0]
+	assert.Equal(t, "CalculateShipping", fn.Name)
+	assert.Equal(t, types.BindingFunc, fn.Type)
+	assert.Equal(t, types.ChangeModified, fn.ChangeType)
+	assert.Equal(t, 3, fn.StartLine)
+	assert.Equal(t, 9, fn.EndLine)
+	assert.Contains(t, fn.OldCode, "amount >= 100")
+	assert.Contains(t, fn.NewCode, "amount >= 500")
+	assert.Contains(t, fn.NewCode, "return 10", "the whole body must be captured, not just changed lines")
+
+	c := symbols[1]
+	assert.Equal(t, "MinItems", c.Name)
+	assert.Equal(t, types.BindingConst, c.Type)
+	assert.Equal(t, "const MinItems = 1", c.OldCode)
+	assert.Equal(t, "const MinItems = 2", c.NewCode)
+}
+
+func TestExtractChangedSymbols_FallbackWhenSourceMismatch(t *testing.T) {
+	// The working tree does not contain the added line, so the diff lines are used instead.
+	sources := mapSources{
+		old: map[string]string{"shop/shipping.go": oldShipping},
+		new: map[string]string{"shop/shipping.go": oldShipping},
+	}
+
+	symbols, err := NewSymbolExtractor(sources).ExtractChangedSymbols(shippingDiff)
+	require.NoError(t, err)
+	for _, sym := range symbols {
+		assert.Zero(t, sym.StartLine)
+	}
+}
+
+func TestParseDiffWithContent_LineNumbers(t *testing.T) {
+	diffs, err := ParseDiffWithContent(shippingDiff)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+
+	assert.Equal(t, []int{5, 14}, diffs[0].AddedLineNumbers)
+	assert.Equal(t, []int{5, 14}, diffs[0].RemovedLineNumbers)
+	assert.Equal(t, []string{"\tif amount >= 500 {", "\tMinItems = 2"}, diffs[0].AddedLines)
+}
+
+func TestExtractSymbolsFromDiffLines_QualifiesMethods(t *testing.T) {
+	names := ExtractSymbolsFromDiffLines([]string{
+		"func (o *Order) Total() float64 {",
+		"func (Invoice) Total() float64 {",
+		"func (s *Set[T]) Add(v T) {",
+		"func CalculateShipping(amount float64) float64 {",
+	})
+	assert.Equal(t, []string{"Order.Total", "Invoice.Total", "Set.Add", "CalculateShipping"}, names)
+}
+
+func TestParseDecls_IgnoreDirective(t *testing.T) {
+	src := `package shop
+
+// CalculateDiscount returns the discount rate.
+//
+//docuguard:ignore reason="promo pricing is documented separately"
+func CalculateDiscount() float64 { return 0.1 }
+
+//docuguard:ignore
+const (
+	A = 1
+	B = 2
+)
+
+// Tax is not ignored.
+const Tax = 0.2
+`
+	decls, err := parseDecls("shop/discount.go", []byte(src))
+	require.NoError(t, err)
+	require.Len(t, decls, 4)
+
+	require.NotNil(t, decls[0].ignore)
+	assert.Equal(t, "CalculateDiscount", decls[0].ignore.Symbol)
+	assert.Equal(t, "promo pricing is documented separately", decls[0].ignore.Reason)
+	assert.Equal(t, 3, decls[0].ignore.Line)
+
+	require.NotNil(t, decls[1].ignore, "a directive on a group covers its specs")
+	assert.Equal(t, "A", decls[1].ignore.Symbol)
+	require.NotNil(t, decls[2].ignore)
+	assert.Nil(t, decls[3].ignore)
+}
@@ -0,0 +1,7 @@
+// Package glob selects files with doublestar patterns.
+//
+// Patterns are slash-separated paths relative to a root directory. A "**"
+// segment matches zero or more directories; every other segment follows
+// path.Match syntax. Exclude patterns and, optionally, .gitignore rules
+// prune the walk.
+package glob
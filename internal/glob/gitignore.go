@@ -0,0 +1,110 @@
+package glob
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// gitIgnore evaluates .gitignore files found under a root directory.
+// Rules are loaded lazily per directory and cached.
+type gitIgnore struct {
+	root  string
+	rules map[string][]ignoreRule
+}
+
+// ignoreRule is one parsed .gitignore line.
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+func newGitIgnore(root string) *gitIgnore {
+	return &gitIgnore{root: root, rules: make(map[string][]ignoreRule)}
+}
+
+// ignored reports whether rel, or one of its parent directories, is ignored.
+func (g *gitIgnore) ignored(rel string, isDir bool) bool {
+	segs := split(rel)
+	for i := 1; i < len(segs); i++ {
+		if g.match(segs[:i], true) {
+			return true
+		}
+	}
+	return g.match(segs, isDir)
+}
+
+// match applies the rules of every .gitignore from the root down to the
+// path's parent; the last matching rule wins, as in git.
+func (g *gitIgnore) match(segs []string, isDir bool) bool {
+	ignored := false
+	for depth := 0; depth < len(segs); depth++ {
+		dir := strings.Join(segs[:depth], "/")
+		rel := strings.Join(segs[depth:], "/")
+		for _, r := range g.load(dir) {
+			if r.dirOnly && !isDir {
+				continue
+			}
+			if Match(r.pattern, rel) {
+				ignored = !r.negate
+			}
+		}
+	}
+	return ignored
+}
+
+func (g *gitIgnore) load(dir string) []ignoreRule {
+	if rules, ok := g.rules[dir]; ok {
+		return rules
+	}
+
+	var rules []ignoreRule
+	f, err := os.Open(filepath.Join(g.root, filepath.FromSlash(dir), ".gitignore"))
+	if err == nil {
+		defer f.Close()
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			if r, ok := parseIgnoreRule(s.Text()); ok {
+				rules = append(rules, r)
+			}
+		}
+	}
+
+	g.rules[dir] = rules
+	return rules
+}
+
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var r ignoreRule
+	if strings.HasPrefix(line, "!") {
+		r.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, `\`)
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	// A pattern with a slash is anchored to the .gitignore's directory;
+	// otherwise it matches at any depth below it.
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		line = path.Join("**", line)
+	}
+	if line == "" || line == "**" {
+		return ignoreRule{}, false
+	}
+
+	r.pattern = line
+	return r, true
+}
@@ -0,0 +1,212 @@
+package glob
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Options controls which files Files returns.
+type Options struct {
+	// Exclude patterns remove matching files; a matching directory is skipped entirely.
+	Exclude []string
+	// GitIgnore skips files ignored by .gitignore files under the root.
+	GitIgnore bool
+}
+
+// Match reports whether name matches the doublestar pattern.
+// Both are slash-separated paths relative to the same root.
+func Match(pattern, name string) bool {
+	return matchSegments(split(pattern), split(name))
+}
+
+// HasMeta reports whether the pattern contains wildcard characters.
+func HasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// Files returns the files under root matched by any include pattern and no
+// exclude pattern. Paths are joined with root and returned in walk order,
+// grouped by pattern, without duplicates. Patterns without wildcards name a
+// file directly and are not subject to .gitignore rules.
+func Files(root string, include []string, opts Options) ([]string, error) {
+	for _, p := range append(append([]string{}, include...), opts.Exclude...) {
+		if err := validate(p); err != nil {
+			return nil, err
+		}
+	}
+
+	w := &walker{root: root, opts: opts}
+	if opts.GitIgnore {
+		w.ignore = newGitIgnore(root)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	add := func(rel string) {
+		file := filepath.Join(root, filepath.FromSlash(rel))
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, pattern := range include {
+		pattern = clean(pattern)
+
+		if !HasMeta(pattern) {
+			info, err := os.Stat(filepath.Join(root, filepath.FromSlash(pattern)))
+			if err == nil && !info.IsDir() && !w.excluded(pattern) {
+				add(pattern)
+			}
+			continue
+		}
+
+		base := baseDir(pattern)
+		if w.skipped(base) {
+			continue
+		}
+		_ = filepath.WalkDir(filepath.Join(root, filepath.FromSlash(base)), func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Unreadable entries are skipped like missing ones.
+				if d != nil && d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			rel, relErr := filepath.Rel(root, p)
+			if relErr != nil {
+				return nil
+			}
+			rel = filepath.ToSlash(rel)
+
+			if d.IsDir() {
+				if rel != base && w.skip(rel, true) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if Match(pattern, rel) && !w.skip(rel, false) {
+				add(rel)
+			}
+			return nil
+		})
+	}
+
+	return files, nil
+}
+
+// walker applies exclude and .gitignore rules during a walk.
+type walker struct {
+	root   string
+	opts   Options
+	ignore *gitIgnore
+}
+
+func (w *walker) excluded(rel string) bool {
+	for _, p := range w.opts.Exclude {
+		if Match(clean(p), rel) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *walker) skip(rel string, isDir bool) bool {
+	if isDir && path.Base(rel) == ".git" {
+		return true
+	}
+	if w.excluded(rel) {
+		return true
+	}
+	return w.ignore != nil && w.ignore.ignored(rel, isDir)
+}
+
+// skipped reports whether dir or any of its parents would be pruned,
+// since a walk that starts below the root never visits them.
+func (w *walker) skipped(dir string) bool {
+	if dir == "." {
+		return false
+	}
+	segs := split(dir)
+	for i := range segs {
+		if w.skip(strings.Join(segs[:i+1], "/"), true) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for len(pattern) > 1 && pattern[1] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// baseDir returns the longest leading directory of the pattern without wildcards.
+func baseDir(pattern string) string {
+	segs := split(pattern)
+	var base []string
+	for _, s := range segs[:len(segs)-1] {
+		if HasMeta(s) {
+			break
+		}
+		base = append(base, s)
+	}
+	if len(base) == 0 {
+		return "."
+	}
+	return strings.Join(base, "/")
+}
+
+func validate(pattern string) error {
+	for _, seg := range split(clean(pattern)) {
+		if seg == "**" {
+			continue
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// clean normalizes a pattern or path to slash-separated form relative to the root.
+func clean(p string) string {
+	p = path.Clean(filepath.ToSlash(p))
+	return strings.TrimPrefix(p, "/")
+}
+
+func split(p string) []string {
+	p = clean(p)
+	if p == "." || p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
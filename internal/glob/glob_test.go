@@ -0,0 +1,103 @@
+package glob
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"docs/**/*.md", "docs/api.md", true},
+		{"docs/**/*.md", "docs/guide/setup/install.md", true},
+		{"docs/**/*.md", "README.md", false},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "a/b/c.md", true},
+		{"docs/*.md", "docs/guide/setup.md", false},
+		{"docs/archive/**", "docs/archive", true},
+		{"docs/archive/**", "docs/archive/old/v1.md", true},
+		{"./README.md", "README.md", true},
+		{"docs/**/api/*.md", "docs/api/v1.md", true},
+		{"docs/**/api/*.md", "docs/x/y/api/v1.md", true},
+		{"docs/**/api/*.md", "docs/x/y/v1.md", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Match(tt.pattern, tt.name), "Match(%q, %q)", tt.pattern, tt.name)
+	}
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func rel(t *testing.T, root string, files []string) []string {
+	t.Helper()
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		r, err := filepath.Rel(root, f)
+		require.NoError(t, err)
+		out = append(out, filepath.ToSlash(r))
+	}
+	return out
+}
+
+func TestFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".gitignore":                "node_modules/\n*.gen.md\n",
+		"README.md":                 "",
+		"docs/api.md":               "",
+		"docs/guide/setup.md":       "",
+		"docs/guide/api.gen.md":     "",
+		"docs/archive/v1.md":        "",
+		"docs/private/.gitignore":   "*\n!keep.md\n",
+		"docs/private/drop.md":      "",
+		"docs/private/keep.md":      "",
+		"node_modules/pkg/index.md": "",
+		".git/info.md":              "",
+	})
+
+	opts := Options{Exclude: []string{"docs/archive/**"}, GitIgnore: true}
+	files, err := Files(root, []string{"README.md", "**/*.md", "docs/**/*.md"}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"README.md",
+		"docs/api.md",
+		"docs/guide/setup.md",
+		"docs/private/keep.md",
+	}, rel(t, root, files))
+
+	files, err = Files(root, []string{"**/*.md"}, Options{})
+	require.NoError(t, err)
+	assert.Len(t, files, 8, "only .git is skipped without exclude and .gitignore")
+}
+
+func TestFiles_LiteralIgnoresGitIgnore(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".gitignore":     "CHANGES.gen.md\n",
+		"CHANGES.gen.md": "",
+	})
+
+	files, err := Files(root, []string{"CHANGES.gen.md", "missing.md"}, Options{GitIgnore: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CHANGES.gen.md"}, rel(t, root, files))
+}
+
+func TestFiles_InvalidPattern(t *testing.T) {
+	_, err := Files(t.TempDir(), []string{"docs/[.md"}, Options{})
+	assert.Error(t, err)
+}
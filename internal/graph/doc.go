@@ -0,0 +1,10 @@
+// Package graph merges the bindings declared on both sides of the
+// documentation-code boundary.
+//
+// Markdown declares bindings with docuguard:bindCode directives inside a
+// docuguard:start/end block. Go declares them in reverse, with a
+// //docuguard:doc directive in a declaration's doc comment naming a
+// Markdown file and heading; the heading is resolved to the section
+// scanner.ScanMarkdown finds. Both kinds end up as types.Binding values, so
+// 'docuguard check' and 'docuguard pr' treat them alike.
+package graph
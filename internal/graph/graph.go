@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/internal/scanner"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// Graph is a set of bindings between documentation and code.
+type Graph struct {
+	bindings []types.Binding
+	seen     map[string]bool
+}
+
+// New returns an empty graph.
+func New() *Graph {
+	return &Graph{seen: make(map[string]bool)}
+}
+
+// Add adds bindings to the graph. A binding of a symbol to a block or
+// section that is already bound to it is dropped.
+func (g *Graph) Add(bindings ...types.Binding) {
+	for _, b := range bindings {
+		key := fmt.Sprintf("%s:%d:%s:%s", clean(b.DocFile), b.BlockLine, clean(b.CodeFile), b.CodeSymbol)
+		if g.seen[key] {
+			continue
+		}
+		g.seen[key] = true
+		g.bindings = append(g.bindings, b)
+	}
+}
+
+// Len returns the number of bindings in the graph.
+func (g *Graph) Len() int {
+	return len(g.bindings)
+}
+
+// ForDoc returns the bindings of a documentation file in line order.
+func (g *Graph) ForDoc(docFile string) []types.Binding {
+	var result []types.Binding
+	for _, b := range g.bindings {
+		if clean(b.DocFile) == clean(docFile) {
+			result = append(result, b)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].DocLine < result[j].DocLine })
+	return result
+}
+
+// ForSymbol returns the bindings of a code symbol.
+func (g *Graph) ForSymbol(codeFile, symbol string) []types.Binding {
+	var result []types.Binding
+	for _, b := range g.bindings {
+		if clean(b.CodeFile) == clean(codeFile) && b.CodeSymbol == symbol {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// Resolver turns //docuguard:doc directives into bindings, scanning each
+// Markdown file once.
+type Resolver struct {
+	segments map[string][]types.DocSegment
+	errs     map[string]error
+}
+
+// NewResolver returns a Resolver with an empty file cache.
+func NewResolver() *Resolver {
+	return &Resolver{
+		segments: make(map[string][]types.DocSegment),
+		errs:     make(map[string]error),
+	}
+}
+
+// Resolve returns the binding a directive declares. The heading matches a
+// section heading case-insensitively, with or without its leading #s.
+func (r *Resolver) Resolve(ref types.DocRef) (types.Binding, error) {
+	if ref.DocFile == "" || ref.Heading == "" {
+		return types.Binding{}, fmt.Errorf("%s:%d: docuguard:doc needs path and heading attributes", ref.CodeFile, ref.Line)
+	}
+
+	docFile := clean(ref.DocFile)
+	segments, ok := r.segments[docFile]
+	if !ok && r.errs[docFile] == nil {
+		segments, r.errs[docFile] = scanner.ScanMarkdown(docFile)
+		r.segments[docFile] = segments
+	}
+	if err := r.errs[docFile]; err != nil {
+		return types.Binding{}, fmt.Errorf("%s:%d: cannot read %s: %w", ref.CodeFile, ref.Line, ref.DocFile, err)
+	}
+
+	heading := strings.TrimSpace(strings.TrimLeft(ref.Heading, "#"))
+	for i := range segments {
+		seg := &segments[i]
+		if !strings.EqualFold(seg.Heading, heading) {
+			continue
+		}
+		return types.Binding{
+			DocFile:      seg.File,
+			DocLine:      seg.StartLine,
+			BlockLine:    seg.StartLine,
+			DocEndLine:   seg.EndLine,
+			DocContent:   seg.Content,
+			Heading:      seg.Heading,
+			CodeFile:     ref.CodeFile,
+			CodeSymbol:   ref.CodeSymbol,
+			CodeType:     ref.CodeType,
+			Suppressions: seg.Suppressions,
+			Segment:      seg,
+		}, nil
+	}
+	return types.Binding{}, fmt.Errorf("%s:%d: %s has no section %q", ref.CodeFile, ref.Line, ref.DocFile, ref.Heading)
+}
+
+// CodeBindings returns the bindings declared by //docuguard:doc directives
+// in the given Go files. Directives that do not resolve to a section, and
+// files that do not parse, are returned as errors and left out.
+func CodeBindings(goParser *parser.GoParser, goFiles []string) ([]types.Binding, []error) {
+	resolver := NewResolver()
+	var bindings []types.Binding
+	var unresolved []error
+	for _, file := range goFiles {
+		refs, err := goParser.DocRefs(file)
+		if err != nil {
+			unresolved = append(unresolved, fmt.Errorf("failed to parse %s: %w", file, err))
+			continue
+		}
+		for _, ref := range refs {
+			b, err := resolver.Resolve(ref)
+			if err != nil {
+				unresolved = append(unresolved, err)
+				continue
+			}
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings, unresolved
+}
+
+// clean normalizes a slash- or OS-separated relative path for comparison.
+func clean(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestCodeBindings(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "api.md")
+	require.NoError(t, os.WriteFile(doc, []byte("# API\n\n## Shipping Calculation\n\nFree over $100.\n\n## Taxes\n\nTax is 8%.\n"), 0644))
+	code := filepath.Join(dir, "shop.go")
+	require.NoError(t, os.WriteFile(code, []byte(`package shop
+
+//docuguard:doc path="`+doc+`" heading="shipping calculation"
+func CalculateShipping(total float64) float64 { return 0 }
+
+//docuguard:doc path="`+doc+`" heading="## Taxes"
+//docuguard:doc path="`+doc+`" heading="Refunds"
+//docuguard:doc path="`+filepath.Join(dir, "missing.md")+`" heading="Taxes"
+func CalculateTax(total float64) float64 { return 0 }
+`), 0644))
+
+	bindings, unresolved := CodeBindings(parser.NewGoParser(), []string{code})
+	require.Len(t, bindings, 2)
+	require.Len(t, unresolved, 2)
+	assert.ErrorContains(t, unresolved[0], `has no section "Refunds"`)
+	assert.ErrorContains(t, unresolved[1], "cannot read")
+
+	b := bindings[0]
+	assert.Equal(t, "CalculateShipping", b.CodeSymbol)
+	assert.Equal(t, doc, b.DocFile)
+	assert.Equal(t, 3, b.DocLine)
+	assert.Equal(t, 3, b.BlockLine)
+	assert.Equal(t, 6, b.DocEndLine)
+	assert.Equal(t, "## Shipping Calculation\n\nFree over $100.", b.DocContent)
+	require.NotNil(t, b.Segment)
+	assert.Equal(t, "Shipping Calculation", b.Segment.Heading)
+	assert.Equal(t, "CalculateTax", bindings[1].CodeSymbol)
+	assert.Equal(t, 7, bindings[1].DocLine)
+}
+
+func TestGraph(t *testing.T) {
+	g := New()
+	g.Add(
+		types.Binding{DocFile: "docs/api.md", DocLine: 9, BlockLine: 8, CodeFile: "shop.go", CodeSymbol: "CalculateTax"},
+		types.Binding{DocFile: "docs/api.md", DocLine: 3, BlockLine: 3, CodeFile: "shop.go", CodeSymbol: "CalculateShipping"},
+		types.Binding{DocFile: "./docs/api.md", DocLine: 3, BlockLine: 3, CodeFile: "./shop.go", CodeSymbol: "CalculateShipping"},
+		types.Binding{DocFile: "README.md", DocLine: 5, BlockLine: 4, CodeFile: "shop.go", CodeSymbol: "CalculateShipping"},
+	)
+	assert.Equal(t, 3, g.Len(), "a symbol is bound to a block once")
+
+	forDoc := g.ForDoc("./docs/api.md")
+	require.Len(t, forDoc, 2)
+	assert.Equal(t, "CalculateShipping", forDoc[0].CodeSymbol)
+	assert.Equal(t, "CalculateTax", forDoc[1].CodeSymbol)
+
+	assert.Len(t, g.ForSymbol("shop.go", "CalculateShipping"), 2)
+	assert.Empty(t, g.ForSymbol("other.go", "CalculateShipping"))
+}
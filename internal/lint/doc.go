@@ -0,0 +1,8 @@
+// Package lint validates docuguard binding annotations without an LLM.
+//
+// It reports problems that 'docuguard check' would otherwise skip silently
+// or turn into misleading findings: blocks that are not closed or are
+// nested, bindCode directives outside a block or with unknown kinds, code
+// paths that do not exist, symbols the code file does not declare, and
+// blocks without documentation.
+package lint
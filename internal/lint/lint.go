@@ -0,0 +1,217 @@
+package lint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/internal/parser"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// Rule names a kind of problem.
+type Rule string
+
+const (
+	// RuleUnclosedBlock is a docuguard:start without a matching docuguard:end.
+	RuleUnclosedBlock Rule = "unclosed-block"
+	// RuleNestedBlock is a docuguard:start inside another block.
+	RuleNestedBlock Rule = "nested-block"
+	// RuleUnmatchedEnd is a docuguard:end without an open block.
+	RuleUnmatchedEnd Rule = "unmatched-end"
+	// RuleMissingBinding is a block without a bindCode directive.
+	RuleMissingBinding Rule = "missing-bindcode"
+	// RuleDuplicateBinding is a bindCode directive repeating one of the same block.
+	RuleDuplicateBinding Rule = "duplicate-bindcode"
+	// RuleBindingOutsideBlock is a bindCode directive outside any block.
+	RuleBindingOutsideBlock Rule = "bindcode-outside-block"
+	// RuleMalformedBinding is a bindCode directive that does not parse.
+	RuleMalformedBinding Rule = "malformed-bindcode"
+	// RuleUnknownKind is a bindCode kind that ParseBindingType does not accept.
+	RuleUnknownKind Rule = "unknown-kind"
+	// RuleUnknownDirective is a docuguard: comment that is not a directive.
+	RuleUnknownDirective Rule = "unknown-directive"
+	// RuleEmptyDoc is a binding block without documentation text.
+	RuleEmptyDoc Rule = "empty-doc"
+	// RuleUnresolvedPath is a bindCode path that does not exist.
+	RuleUnresolvedPath Rule = "unresolved-path"
+	// RuleUnparsableCode is a bound Go file that does not parse.
+	RuleUnparsableCode Rule = "unparsable-code"
+	// RuleUnknownSymbol is a bound symbol the Go file does not declare.
+	RuleUnknownSymbol Rule = "unknown-symbol"
+)
+
+// Diagnostic is a problem at a line of a documentation file.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as file:line: message (rule).
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s)", d.File, d.Line, d.Message, d.Rule)
+}
+
+// directiveRe matches any docuguard directive comment and captures its name.
+var directiveRe = regexp.MustCompile(`<!--\s*docuguard:([\w-]+)`)
+
+// knownDirectives are the directive names recognized in Markdown.
+var knownDirectives = map[string]bool{
+	"start":       true,
+	"end":         true,
+	"bindCode":    true,
+	"ignore":      true,
+	"ignore-file": true,
+}
+
+// File lints the bindings of a Markdown file. Bound code paths are resolved
+// from the working directory, as 'docuguard check' does.
+func File(docPath string, goParser *parser.GoParser) ([]Diagnostic, error) {
+	diags, badKinds, err := structure(docPath)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := parser.ExtractBindings(docPath)
+	if err != nil {
+		return nil, err
+	}
+	emptyBlocks := make(map[int]bool)
+	for _, b := range bindings {
+		// Bindings of one block share its documentation; report it once.
+		if strings.TrimSpace(b.DocContent) == "" && !emptyBlocks[b.BlockLine] {
+			emptyBlocks[b.BlockLine] = true
+			diags = append(diags, Diagnostic{File: b.DocFile, Line: b.DocLine, Rule: RuleEmptyDoc,
+				Message: fmt.Sprintf("binding block for %s has no documentation", b.CodeSymbol)})
+		}
+		diags = append(diags, resolve(b, goParser, badKinds[b.DocLine])...)
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Line < diags[j].Line })
+	return diags, nil
+}
+
+// structure checks the directives line by line, the way ExtractBindings
+// reads them. It also returns the lines of bindCode directives with an
+// unknown kind, whose symbols are not looked up.
+func structure(docPath string) ([]Diagnostic, map[int]bool, error) {
+	file, err := os.Open(docPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	var diags []Diagnostic
+	report := func(line int, rule Rule, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{File: docPath, Line: line, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+	badKinds := make(map[int]bool)
+
+	// bound maps the bindCode directives of the open block to their lines.
+	blockStart := 0
+	var bound map[parser.BindCode]int
+	closeBlock := func() {
+		if len(bound) == 0 {
+			report(blockStart, RuleMissingBinding, "binding block has no docuguard:bindCode directive")
+		}
+		blockStart, bound = 0, nil
+	}
+
+	lineNum := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		if _, _, ok := parser.ParseIgnore(line); ok {
+			continue
+		}
+
+		directive, bind, ok := parser.BindingDirective(line)
+		if !ok {
+			if m := directiveRe.FindStringSubmatch(line); m != nil {
+				if m[1] == "bindCode" {
+					report(lineNum, RuleMalformedBinding, `malformed bindCode, expected <!-- docuguard:bindCode path="file.go" func="Name" -->`)
+				} else if !knownDirectives[m[1]] {
+					report(lineNum, RuleUnknownDirective, "unknown directive docuguard:%s", m[1])
+				}
+			}
+			continue
+		}
+
+		switch directive {
+		case "start":
+			if blockStart != 0 {
+				report(lineNum, RuleNestedBlock, "docuguard:start inside the block opened at line %d", blockStart)
+				closeBlock()
+			}
+			blockStart = lineNum
+			bound = make(map[parser.BindCode]int)
+
+		case "end":
+			if blockStart == 0 {
+				report(lineNum, RuleUnmatchedEnd, "docuguard:end without a docuguard:start")
+				continue
+			}
+			closeBlock()
+
+		case "bindCode":
+			if _, known := parser.ParseBindingType(bind.Kind); !known {
+				report(lineNum, RuleUnknownKind, "unknown binding kind %q, expected func, method, struct, interface, type, const, constblock or var", bind.Kind)
+				badKinds[lineNum] = true
+			}
+			key := *bind
+			switch {
+			case blockStart == 0:
+				report(lineNum, RuleBindingOutsideBlock, "docuguard:bindCode outside a docuguard:start/end block is ignored")
+			case bound[key] != 0:
+				report(lineNum, RuleDuplicateBinding, "%s is already bound at line %d", bind.Symbol, bound[key])
+			default:
+				bound[key] = lineNum
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if blockStart != 0 {
+		report(blockStart, RuleUnclosedBlock, "docuguard:start has no matching docuguard:end")
+	}
+	return diags, badKinds, nil
+}
+
+// resolve checks that the code a binding refers to exists.
+func resolve(b types.Binding, goParser *parser.GoParser, badKind bool) []Diagnostic {
+	var diags []Diagnostic
+	report := func(rule Rule, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{File: b.DocFile, Line: b.DocLine, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if _, err := os.Stat(b.CodeFile); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			report(RuleUnresolvedPath, "code file %s does not exist", b.CodeFile)
+		} else {
+			report(RuleUnresolvedPath, "code file %s: %v", b.CodeFile, err)
+		}
+		return diags
+	}
+	if badKind {
+		return diags
+	}
+
+	code, _, err := goParser.ExtractSymbol(b.CodeFile, b.CodeSymbol, b.CodeType)
+	if err != nil {
+		report(RuleUnparsableCode, "cannot parse %s: %v", b.CodeFile, err)
+	} else if code == "" {
+		report(RuleUnknownSymbol, "%s %s not found in %s", b.CodeType, b.CodeSymbol, b.CodeFile)
+	}
+	return diags
+}
@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/parser"
+)
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	code := filepath.Join(dir, "shop.go")
+	require.NoError(t, os.WriteFile(code, []byte(`package shop
+
+const FreeShippingThreshold = 100
+
+func CalculateShipping(total float64) float64 { return 0 }
+`), 0644))
+
+	doc := strings.NewReplacer("CODE", code, "MISSING", filepath.Join(dir, "missing.go")).Replace(`# Shop
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="CODE" func="CalculateShipping" -->
+<!-- docuguard:bindCode path="CODE" const="FreeShippingThreshold" -->
+<!-- docuguard:bindCode path="CODE" func="CalculateShipping" -->
+Shipping is free over $100.
+<!-- docuguard:end -->
+
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="CODE" func="CalculateTax" -->
+Tax is 8%.
+<!-- docuguard:end -->
+
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="MISSING" const="Rate" -->
+<!-- docuguard:end -->
+
+<!-- docuguard:bindCode path="CODE" const="FreeShippingThreshold" -->
+
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="CODE" constant="FreeShippingThreshold" -->
+The threshold is $100.
+<!-- docuguard:end -->
+<!-- docuguard:end -->
+
+<!-- docuguard:strat -->
+<!-- docuguard:bindCode func="CalculateShipping" -->
+<!-- docuguard:start -->
+Dangling.
+`)
+	docPath := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(docPath, []byte(doc), 0644))
+
+	diags, err := File(docPath, parser.NewGoParser())
+	require.NoError(t, err)
+
+	var got []string
+	for _, d := range diags {
+		assert.Equal(t, docPath, d.File)
+		got = append(got, strings.TrimPrefix(d.String(), docPath+":"))
+	}
+	assert.Equal(t, []string{
+		"5: CalculateShipping is already bound at line 3 (duplicate-bindcode)",
+		"10: func CalculateTax not found in " + code + " (unknown-symbol)",
+		"15: binding block for Rate has no documentation (empty-doc)",
+		"15: code file " + filepath.Join(dir, "missing.go") + " does not exist (unresolved-path)",
+		"18: docuguard:bindCode outside a docuguard:start/end block is ignored (bindcode-outside-block)",
+		`21: unknown binding kind "constant", expected func, method, struct, interface, type, const, constblock or var (unknown-kind)`,
+		"24: docuguard:end without a docuguard:start (unmatched-end)",
+		"26: unknown directive docuguard:strat (unknown-directive)",
+		`27: malformed bindCode, expected <!-- docuguard:bindCode path="file.go" func="Name" --> (malformed-bindcode)`,
+		"28: docuguard:start has no matching docuguard:end (unclosed-block)",
+	}, got)
+}
@@ -3,26 +3,44 @@ package llm
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
 
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
+const (
+	// anthropicVersion is the Messages API version sent with every request.
+	anthropicVersion = "2023-06-01"
+	// anthropicMaxTokens caps the length of a verdict; responses are short JSON objects.
+	anthropicMaxTokens = 1024
+)
+
 // AnthropicClient Anthropic 客户端
 type AnthropicClient struct {
+	client  *resty.Client
 	model   string
-	apiKey  string
 	baseURL string
 }
 
 // NewAnthropicClient 创建 Anthropic 客户端
-func NewAnthropicClient(model, apiKey, baseURL string) (*AnthropicClient, error) {
+func NewAnthropicClient(model, apiKey, baseURL string, timeout time.Duration) (*AnthropicClient, error) {
 	if baseURL == "" {
 		baseURL = "https://api.anthropic.com/v1"
 	}
 
+	client := resty.New().
+		SetBaseURL(baseURL).
+		SetHeader("x-api-key", apiKey).
+		SetHeader("anthropic-version", anthropicVersion).
+		SetHeader("Content-Type", "application/json").
+		SetTimeout(timeout)
+
 	return &AnthropicClient{
+		client:  client,
 		model:   model,
-		apiKey:  apiKey,
 		baseURL: baseURL,
 	}, nil
 }
@@ -31,12 +49,112 @@ func (c *AnthropicClient) Name() string {
 	return "anthropic"
 }
 
+// Analyze 执行分析
 func (c *AnthropicClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
-	// TODO: 实现 Anthropic API 调用
-	return nil, fmt.Errorf("anthropic client not implemented yet")
+	content, err := c.createMessage(ctx, systemPrompt, buildPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckResult(content)
+}
+
+// AnalyzePR checks a documentation segment against the code before and after a change.
+func (c *AnthropicClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	prompt, err := buildPRPrompt(req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.createMessage(ctx, prSystemPrompt, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckResult(content)
 }
 
+// CheckRelevanceBatch checks relevance of multiple document segments for a symbol in one LLM call.
 func (c *AnthropicClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
-	// TODO: 实现 Anthropic API 调用
-	return nil, fmt.Errorf("anthropic client not implemented yet")
+	if len(req.Candidates) == 0 {
+		return nil, nil
+	}
+
+	prompt, err := buildRelevancePrompt(req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.createMessage(ctx, relevanceSystemPrompt, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return parseRelevantIndices(content, len(req.Candidates))
+}
+
+// AnalyzeBlock checks a documentation block against each symbol bound to it in one request.
+func (c *AnthropicClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	content, err := c.createMessage(ctx, blockSystemPrompt, buildBlockPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	return parseBlockResults(content, req.Symbols)
+}
+
+// RewriteDoc asks for a corrected version of an inconsistent documentation block.
+func (c *AnthropicClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	content, err := c.createMessage(ctx, rewriteSystemPrompt, buildRewritePrompt(req))
+	if err != nil {
+		return "", err
+	}
+	return parseRewrite(content)
+}
+
+// createMessage sends a single-turn request to the Messages API and returns
+// the concatenated text blocks of the reply.
+func (c *AnthropicClient) createMessage(ctx context.Context, system, prompt string) (string, error) {
+	payload := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": anthropicMaxTokens,
+		"system":     system,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"temperature": 0.1,
+	}
+
+	var response struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(payload).
+		SetResult(&response).
+		Post("/messages")
+
+	if err != nil {
+		return "", fmt.Errorf("API request failed: %w", err)
+	}
+
+	if resp.IsError() {
+		return "", newAPIError(resp)
+	}
+	reportUsage(ctx, response.Usage.InputTokens, response.Usage.OutputTokens)
+
+	var sb strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			sb.WriteString(block.Text)
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("no response from API")
+	}
+
+	return sb.String(), nil
 }
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func newAnthropicTestServer(t *testing.T, reply string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+
+		var body struct {
+			Model    string `json:"model"`
+			System   string `json:"system"`
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "claude-test", body.Model)
+		assert.NotEmpty(t, body.System)
+		require.Len(t, body.Messages, 1)
+		assert.Equal(t, "user", body.Messages[0].Role)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"content": []map[string]string{{"type": "text", "text": reply}},
+			"usage":   map[string]int{"input_tokens": 120, "output_tokens": 30},
+		})
+	}))
+}
+
+func TestAnthropicClient_Analyze(t *testing.T) {
+	srv := newAnthropicTestServer(t, "```json\n{\"related\": true, \"consistent\": false, \"confidence\": 0.9, \"reason\": \"threshold differs\"}\n```")
+	defer srv.Close()
+
+	client, err := NewAnthropicClient("claude-test", "test-key", srv.URL+"/v1", 0)
+	require.NoError(t, err)
+
+	result, err := client.Analyze(context.Background(), AnalyzeRequest{
+		DocContent:  "Orders over 500 ship free.",
+		CodeContent: "func CalculateShipping() {}",
+		CodeSymbol:  "CalculateShipping",
+		CodeFile:    "payment.go",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Related)
+	assert.False(t, result.Consistent)
+	assert.Equal(t, 0.9, result.Confidence)
+	assert.Equal(t, "threshold differs", result.Reason)
+}
+
+func TestAnthropicClient_CheckRelevanceBatch(t *testing.T) {
+	srv := newAnthropicTestServer(t, `{"relevant": [1, 5]}`)
+	defer srv.Close()
+
+	client, err := NewAnthropicClient("claude-test", "test-key", srv.URL+"/v1", 0)
+	require.NoError(t, err)
+
+	indices, err := client.CheckRelevanceBatch(context.Background(), RelevanceRequest{
+		Symbol:     types.ChangedSymbol{Name: "CalculateShipping"},
+		Candidates: []types.DocSegment{{Heading: "A"}, {Heading: "B"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, indices)
+}
@@ -0,0 +1,229 @@
+package llm
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+const (
+	// DefaultContextTokens is the context window assumed for unknown models.
+	DefaultContextTokens = 8192
+	// ReserveTokens is kept free for the system prompt, the instructions
+	// around the documentation and code, and the response.
+	ReserveTokens = 2048
+	// CandidateTokens caps each candidate of a relevance request; the model
+	// only needs the start of a section to judge what it is about.
+	CandidateTokens = 128
+	// ChunkOverlapLines is the number of lines a chunk repeats from the end
+	// of the previous one, so a statement split between them is seen whole.
+	ChunkOverlapLines = 3
+)
+
+// contextWindows maps lowercase model name prefixes to their context
+// windows. The longest matching prefix wins, whatever the case of the model.
+var contextWindows = map[string]int{
+	"gpt-3.5-turbo": 16385,
+	"gpt-4":         8192,
+	"gpt-4-32k":     32768,
+	"gpt-4-turbo":   128000,
+	"gpt-4o":        128000,
+	"gpt-4.1":       1047576,
+	"o1":            200000,
+	"o3":            200000,
+	"o4":            200000,
+	"claude":        200000,
+	"llama2":        4096,
+	"llama3":        8192,
+	"llama3.1":      131072,
+	"mistral":       32768,
+	"qwen":          32768,
+}
+
+// Budget bounds the size of the documentation and code sent in one request.
+type Budget struct {
+	// ContextTokens is the context window of the model.
+	ContextTokens int
+}
+
+// NewBudget returns the budget for a model. A positive contextTokens
+// overrides the window known for the model.
+func NewBudget(model string, contextTokens int) Budget {
+	if contextTokens > 0 {
+		return Budget{ContextTokens: contextTokens}
+	}
+	model = strings.ToLower(model)
+	window, matched := DefaultContextTokens, 0
+	for prefix, tokens := range contextWindows {
+		if strings.HasPrefix(model, prefix) && len(prefix) > matched {
+			window, matched = tokens, len(prefix)
+		}
+	}
+	return Budget{ContextTokens: window}
+}
+
+// Input returns the tokens available for documentation and code.
+func (b Budget) Input() int {
+	if b.ContextTokens <= 2*ReserveTokens {
+		return b.ContextTokens / 2
+	}
+	return b.ContextTokens - ReserveTokens
+}
+
+// EstimateTokens approximates the number of tokens s takes with a BPE
+// tokenizer: about four ASCII letters or digits per token, one per
+// punctuation mark or line break, and one per non-ASCII rune, which is
+// close for Chinese and Japanese and errs on the high side elsewhere.
+func EstimateTokens(s string) int {
+	tokens, word := 0, 0
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word++
+			continue
+		case r == ' ':
+			// A space is merged into the token of the following word.
+		case r < utf8.RuneSelf || !unicode.IsSpace(r):
+			tokens++
+		}
+		tokens += (word + 3) / 4
+		word = 0
+	}
+	return tokens + (word+3)/4
+}
+
+// Truncate shortens s to about maxTokens tokens. It cuts after the last
+// line that fits, or inside the first line on a rune boundary when even
+// that line does not fit, and marks the cut with "...".
+func Truncate(s string, maxTokens int) string {
+	if EstimateTokens(s) <= maxTokens {
+		return s
+	}
+	limit := maxTokens - EstimateTokens("\n...")
+	if limit <= 0 {
+		return "..."
+	}
+
+	// used is the estimate of s[:i], which ends with a line break.
+	cut, used := 0, 0
+	for i := 0; i < len(s); {
+		next := strings.IndexByte(s[i:], '\n')
+		if next < 0 {
+			break
+		}
+		if used += EstimateTokens(s[i : i+next]); used > limit {
+			break
+		}
+		cut, i = i+next, i+next+1
+		used++
+	}
+	if cut > 0 {
+		return s[:cut] + "\n..."
+	}
+	return truncateRunes(s, limit) + "..."
+}
+
+// truncateRunes returns the longest prefix of s that ends on a rune
+// boundary and fits maxTokens.
+func truncateRunes(s string, maxTokens int) string {
+	bounds := make([]int, 0, len(s)+1)
+	for i := range s {
+		bounds = append(bounds, i)
+	}
+	bounds = append(bounds, len(s))
+	// The estimate never shrinks as the prefix grows.
+	n := sort.Search(len(bounds), func(i int) bool {
+		return EstimateTokens(s[:bounds[i]]) > maxTokens
+	})
+	if n == 0 {
+		return ""
+	}
+	return s[:bounds[n-1]]
+}
+
+// Chunk is a part of a text that fits a token budget.
+type Chunk struct {
+	// Text is the content of the chunk.
+	Text string
+	// Line is the index of the chunk's first line in the text.
+	Line int
+	// Lines is the number of lines the chunk spans.
+	Lines int
+}
+
+// ChunkText splits s on line boundaries into chunks of at most maxTokens,
+// each repeating the last overlapLines lines of the one before. A line
+// longer than the budget is split on rune boundaries. Text that fits is
+// returned as one chunk.
+func ChunkText(s string, maxTokens, overlapLines int) []Chunk {
+	if maxTokens <= 0 || EstimateTokens(s) <= maxTokens {
+		return []Chunk{{Text: s, Lines: strings.Count(s, "\n") + 1}}
+	}
+
+	lines := strings.Split(s, "\n")
+	// Line breaks count one token each, so the estimate of lines[i:j]
+	// joined is sum[j] - sum[i] + j - i - 1.
+	sum := make([]int, len(lines)+1)
+	for i, line := range lines {
+		sum[i+1] = sum[i] + EstimateTokens(line)
+	}
+	fits := func(i, j int) bool { return sum[j]-sum[i]+j-i-1 <= maxTokens }
+
+	var chunks []Chunk
+	for start := 0; start < len(lines); {
+		if !fits(start, start+1) {
+			for rest := lines[start]; rest != ""; {
+				piece := truncateRunes(rest, maxTokens)
+				if piece == "" {
+					// A single rune over budget still has to go somewhere.
+					_, size := utf8.DecodeRuneInString(rest)
+					piece = rest[:size]
+				}
+				chunks = append(chunks, Chunk{Text: piece, Line: start, Lines: 1})
+				rest = rest[len(piece):]
+			}
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(lines) && fits(start, end+1) {
+			end++
+		}
+		chunks = append(chunks, Chunk{Text: strings.Join(lines[start:end], "\n"), Line: start, Lines: end - start})
+		if end == len(lines) {
+			break
+		}
+
+		// At most half of a chunk is repeated, and the overlap must leave
+		// room for the next new line.
+		next := end - min(overlapLines, (end-start)/2)
+		for next < end && !fits(next, end+1) {
+			next++
+		}
+		start = next
+	}
+	return chunks
+}
+
+// ChunkSegment splits a documentation segment whose content exceeds
+// maxTokens into overlapping segments of the same file and heading, with
+// line numbers narrowed to the part each one holds.
+func ChunkSegment(seg types.DocSegment, maxTokens, overlapLines int) []types.DocSegment {
+	chunks := ChunkText(seg.Content, maxTokens, overlapLines)
+	if len(chunks) == 1 {
+		return []types.DocSegment{seg}
+	}
+	segments := make([]types.DocSegment, len(chunks))
+	for i, c := range chunks {
+		part := seg
+		part.Content = c.Text
+		part.StartLine = seg.StartLine + c.Line
+		part.EndLine = part.StartLine + c.Lines - 1
+		segments[i] = part
+	}
+	return segments
+}
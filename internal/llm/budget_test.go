@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestNewBudget(t *testing.T) {
+	assert.Equal(t, 8192, NewBudget("gpt-4", 0).ContextTokens)
+	assert.Equal(t, 128000, NewBudget("gpt-4o-mini", 0).ContextTokens, "longest prefix wins")
+	assert.Equal(t, 200000, NewBudget("claude-3-5-sonnet-latest", 0).ContextTokens)
+	assert.Equal(t, 131072, NewBudget("Llama3.1:70B", 0).ContextTokens, "prefixes match in any case")
+	assert.Equal(t, DefaultContextTokens, NewBudget("my-finetune", 0).ContextTokens)
+	assert.Equal(t, 32000, NewBudget("gpt-4", 32000).ContextTokens, "configured size overrides the model")
+
+	assert.Equal(t, 8192-ReserveTokens, NewBudget("gpt-4", 0).Input())
+	assert.Equal(t, 1000, Budget{ContextTokens: 2000}.Input(), "small windows keep half for the rest")
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 2, EstimateTokens("shipping"))
+	assert.Equal(t, 4, EstimateTokens("free shipping."))
+	assert.Equal(t, 4, EstimateTokens("满额包邮"))
+	assert.Equal(t, 4, EstimateTokens("a\nb\n"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 10))
+
+	text := "line one\nline two\nline three"
+	assert.Equal(t, "line one\n...", Truncate(text, 7), "cuts after the last line that fits")
+
+	// A single line is cut between runes, never inside one.
+	cjk := strings.Repeat("订单满一百元免运费。", 20)
+	got := Truncate(cjk, 30)
+	require.True(t, utf8.ValidString(got))
+	assert.True(t, strings.HasSuffix(got, "..."))
+	assert.LessOrEqual(t, EstimateTokens(got), 30)
+	assert.True(t, strings.HasPrefix(cjk, strings.TrimSuffix(got, "...")))
+}
+
+func TestChunkText(t *testing.T) {
+	var lines []string
+	for i := 0; i < 40; i++ {
+		lines = append(lines, "运费规则 shipping rule number")
+	}
+	text := strings.Join(lines, "\n")
+
+	chunks := ChunkText(text, 60, 2)
+	require.Greater(t, len(chunks), 1)
+	assert.Equal(t, 0, chunks[0].Line)
+	for i, c := range chunks {
+		assert.LessOrEqual(t, EstimateTokens(c.Text), 60)
+		assert.Equal(t, strings.Join(lines[c.Line:c.Line+c.Lines], "\n"), c.Text)
+		if i > 0 {
+			prev := chunks[i-1]
+			assert.Equal(t, prev.Line+prev.Lines-2, c.Line, "chunks overlap by two lines")
+		}
+	}
+	last := chunks[len(chunks)-1]
+	assert.Equal(t, len(lines), last.Line+last.Lines, "every line is covered")
+
+	assert.Len(t, ChunkText("fits", 60, 2), 1)
+
+	// A line over the budget is split on rune boundaries.
+	long := ChunkText(strings.Repeat("界", 50), 20, 2)
+	require.Len(t, long, 3)
+	for _, c := range long {
+		assert.True(t, utf8.ValidString(c.Text))
+	}
+}
+
+func TestChunkSegment(t *testing.T) {
+	seg := types.DocSegment{File: "docs/shop.md", StartLine: 10, EndLine: 19, Heading: "Shipping",
+		Content: "## Shipping\n" + strings.Repeat("Orders over $100 ship free.\n", 8) + "Returns are free."}
+
+	parts := ChunkSegment(seg, 40, 1)
+	require.Greater(t, len(parts), 1)
+	assert.Equal(t, 10, parts[0].StartLine)
+	for _, p := range parts {
+		assert.Equal(t, "Shipping", p.Heading)
+		assert.Equal(t, p.StartLine+strings.Count(p.Content, "\n"), p.EndLine)
+	}
+	assert.Equal(t, 19, parts[len(parts)-1].EndLine)
+
+	assert.Equal(t, []types.DocSegment{seg}, ChunkSegment(seg, 1000, 1))
+}
+
+func TestBuildRelevancePrompt_TruncatesOnRunes(t *testing.T) {
+	prompt, err := buildRelevancePrompt(RelevanceRequest{
+		Symbol:     types.ChangedSymbol{Name: "CalculateShipping", Type: types.BindingFunc, File: "shop.go"},
+		Candidates: []types.DocSegment{{File: "docs/运费.md", Heading: "运费", Content: strings.Repeat("满一百元免运费。", 100)}},
+	})
+	require.NoError(t, err)
+	assert.True(t, utf8.ValidString(prompt))
+	assert.Contains(t, prompt, "...")
+}
@@ -0,0 +1,131 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/blueberrycongee/docuguard/internal/cache"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// CachedClient wraps a Client and reuses verdicts for requests whose
+// content has been analyzed before. Cache failures never fail a call.
+type CachedClient struct {
+	inner Client
+	store *cache.Store
+	model string
+}
+
+// NewCachedClient wraps inner with the given store. The model name is part
+// of every cache key so switching models invalidates prior verdicts.
+func NewCachedClient(inner Client, store *cache.Store, model string) *CachedClient {
+	return &CachedClient{
+		inner: inner,
+		store: store,
+		model: model,
+	}
+}
+
+func (c *CachedClient) Name() string {
+	return c.inner.Name()
+}
+
+// Analyze returns a cached verdict for the same doc/code content or calls the wrapped client.
+func (c *CachedClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
+	key := cache.Key("analyze", c.inner.Name(), c.model, PromptVersion,
+		req.DocContent, req.CodeContent, req.CodeSymbol, req.CodeFile)
+
+	var cached types.CheckResult
+	if hit, err := c.store.Get(key, &cached); err == nil && hit {
+		return &cached, nil
+	}
+
+	result, err := c.inner.Analyze(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.store.Put(key, result)
+	return result, nil
+}
+
+// AnalyzePR returns a cached verdict for the same doc segment and code change or calls the wrapped client.
+func (c *CachedClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	key := cache.Key("analyze_pr", c.inner.Name(), c.model, PromptVersion,
+		req.Segment.File, req.Segment.Heading, req.Segment.Content,
+		req.Symbol.File, req.Symbol.Name, string(req.Symbol.Type), string(req.Symbol.ChangeType),
+		req.Symbol.OldCode, req.Symbol.NewCode)
+
+	var cached types.CheckResult
+	if hit, err := c.store.Get(key, &cached); err == nil && hit {
+		return &cached, nil
+	}
+
+	result, err := c.inner.AnalyzePR(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.store.Put(key, result)
+	return result, nil
+}
+
+// CheckRelevanceBatch returns cached indices for the same symbol and candidates or calls the wrapped client.
+func (c *CachedClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
+	parts := []string{"relevance", c.inner.Name(), c.model, PromptVersion,
+		req.Symbol.File, req.Symbol.Name, string(req.Symbol.Type), string(req.Symbol.ChangeType),
+		req.Symbol.OldCode, req.Symbol.NewCode}
+	for _, seg := range req.Candidates {
+		parts = append(parts, seg.File, seg.Heading, seg.Content)
+	}
+	key := cache.Key(parts...)
+
+	var cached []int
+	if hit, err := c.store.Get(key, &cached); err == nil && hit {
+		return cached, nil
+	}
+
+	indices, err := c.inner.CheckRelevanceBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.store.Put(key, indices)
+	return indices, nil
+}
+
+// AnalyzeBlock returns cached verdicts for the same block and bound code or calls the wrapped client.
+func (c *CachedClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	parts := []string{"analyze_block", c.inner.Name(), c.model, PromptVersion, req.DocContent}
+	for _, sym := range req.Symbols {
+		parts = append(parts, sym.CodeFile, sym.CodeSymbol, sym.CodeContent)
+	}
+	key := cache.Key(parts...)
+
+	var cached []*types.CheckResult
+	if hit, err := c.store.Get(key, &cached); err == nil && hit && len(cached) == len(req.Symbols) {
+		return cached, nil
+	}
+
+	results, err := c.inner.AnalyzeBlock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.store.Put(key, results)
+	return results, nil
+}
+
+// RewriteDoc returns a cached rewrite for the same documentation, code and verdict or calls the wrapped client.
+func (c *CachedClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	key := cache.Key("rewrite", c.inner.Name(), c.model, PromptVersion,
+		req.DocFile, req.DocContent, req.CodeFile, req.CodeSymbol,
+		req.OldCode, req.CodeContent, req.Reason, req.Suggestion)
+
+	var cached string
+	if hit, err := c.store.Get(key, &cached); err == nil && hit {
+		return cached, nil
+	}
+
+	content, err := c.inner.RewriteDoc(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	_ = c.store.Put(key, content)
+	return content, nil
+}
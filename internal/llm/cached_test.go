@@ -0,0 +1,29 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/internal/cache"
+)
+
+func TestCachedClient_ReusesVerdict(t *testing.T) {
+	inner := &flakyClient{}
+	client := NewCachedClient(inner, cache.NewStore(t.TempDir()), "gpt-4")
+	req := AnalyzeRequest{DocContent: "doc", CodeContent: "code", CodeSymbol: "Foo", CodeFile: "foo.go"}
+
+	_, err := client.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	result, err := client.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, result.Consistent)
+	assert.Equal(t, 1, inner.calls)
+
+	req.CodeContent = "changed code"
+	_, err = client.Analyze(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls)
+}
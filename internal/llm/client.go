@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"time"
 
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
@@ -10,8 +11,17 @@ import (
 type Client interface {
 	// Analyze 分析文档与代码的一致性
 	Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error)
+	// AnalyzePR checks whether a documentation segment still holds after a code change,
+	// using the symbol's code before and after the change.
+	AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error)
 	// CheckRelevanceBatch 批量检查文档段落与代码符号的相关性
 	CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error)
+	// AnalyzeBlock checks a documentation block bound to several symbols in
+	// one request and returns a verdict per symbol, in request order.
+	AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error)
+	// RewriteDoc returns a corrected version of a documentation block that
+	// contradicts the code.
+	RewriteDoc(ctx context.Context, req RewriteRequest) (string, error)
 	// Name 返回客户端名称
 	Name() string
 }
@@ -25,15 +35,16 @@ type AnalyzeRequest struct {
 }
 
 // NewClient 根据配置创建 LLM 客户端
-func NewClient(provider, model, apiKey, baseURL string) (Client, error) {
+// A zero timeout leaves requests bounded only by the context.
+func NewClient(provider, model, apiKey, baseURL string, timeout time.Duration) (Client, error) {
 	switch provider {
 	case "openai":
-		return NewOpenAIClient(model, apiKey, baseURL)
+		return NewOpenAIClient(model, apiKey, baseURL, timeout)
 	case "anthropic":
-		return NewAnthropicClient(model, apiKey, baseURL)
+		return NewAnthropicClient(model, apiKey, baseURL, timeout)
 	case "ollama":
-		return NewOllamaClient(model, baseURL)
+		return NewOllamaClient(model, baseURL, timeout)
 	default:
-		return NewOpenAIClient(model, apiKey, baseURL)
+		return NewOpenAIClient(model, apiKey, baseURL, timeout)
 	}
 }
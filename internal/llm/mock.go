@@ -8,8 +8,11 @@ import (
 
 // MockClient 测试用 Mock 客户端
 type MockClient struct {
-	Result          *types.CheckResult
+	Result *types.CheckResult
+	// BlockResults are returned by AnalyzeBlock; nil repeats Result for every symbol.
+	BlockResults    []*types.CheckResult
 	RelevantIndices []int
+	Rewrite         string
 	Err             error
 }
 
@@ -28,9 +31,37 @@ func (c *MockClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.Ch
 	return c.Result, nil
 }
 
+func (c *MockClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.Result, nil
+}
+
+func (c *MockClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if c.BlockResults != nil {
+		return c.BlockResults, nil
+	}
+	results := make([]*types.CheckResult, len(req.Symbols))
+	for i := range results {
+		results[i] = c.Result
+	}
+	return results, nil
+}
+
 func (c *MockClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
 	if c.Err != nil {
 		return nil, c.Err
 	}
 	return c.RelevantIndices, nil
 }
+
+func (c *MockClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	if c.Err != nil {
+		return "", c.Err
+	}
+	return c.Rewrite, nil
+}
@@ -3,23 +3,36 @@ package llm
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
 
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
 // OllamaClient Ollama 本地模型客户端
 type OllamaClient struct {
+	client  *resty.Client
 	model   string
 	baseURL string
 }
 
 // NewOllamaClient 创建 Ollama 客户端
-func NewOllamaClient(model, baseURL string) (*OllamaClient, error) {
+func NewOllamaClient(model, baseURL string, timeout time.Duration) (*OllamaClient, error) {
+	if model == "" {
+		return nil, fmt.Errorf("ollama provider needs llm.model, e.g. \"llama3.1\"")
+	}
 	if baseURL == "" {
 		baseURL = "http://localhost:11434"
 	}
 
+	client := resty.New().
+		SetBaseURL(baseURL).
+		SetHeader("Content-Type", "application/json").
+		SetTimeout(timeout)
+
 	return &OllamaClient{
+		client:  client,
 		model:   model,
 		baseURL: baseURL,
 	}, nil
@@ -29,12 +42,105 @@ func (c *OllamaClient) Name() string {
 	return "ollama"
 }
 
+// Analyze 执行分析
 func (c *OllamaClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
-	// TODO: 实现 Ollama API 调用
-	return nil, fmt.Errorf("ollama client not implemented yet")
+	content, err := c.chat(ctx, systemPrompt, buildPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckResult(content)
+}
+
+// AnalyzePR checks a documentation segment against the code before and after a change.
+func (c *OllamaClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	prompt, err := buildPRPrompt(req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.chat(ctx, prSystemPrompt, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckResult(content)
 }
 
+// CheckRelevanceBatch checks relevance of multiple document segments for a symbol in one LLM call.
 func (c *OllamaClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
-	// TODO: 实现 Ollama API 调用
-	return nil, fmt.Errorf("ollama client not implemented yet")
+	if len(req.Candidates) == 0 {
+		return nil, nil
+	}
+
+	prompt, err := buildRelevancePrompt(req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.chat(ctx, relevanceSystemPrompt, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return parseRelevantIndices(content, len(req.Candidates))
+}
+
+// AnalyzeBlock checks a documentation block against each symbol bound to it in one request.
+func (c *OllamaClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	content, err := c.chat(ctx, blockSystemPrompt, buildBlockPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	return parseBlockResults(content, req.Symbols)
+}
+
+// RewriteDoc asks for a corrected version of an inconsistent documentation block.
+func (c *OllamaClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	content, err := c.chat(ctx, rewriteSystemPrompt, buildRewritePrompt(req))
+	if err != nil {
+		return "", err
+	}
+	return parseRewrite(content)
+}
+
+// chat sends a non-streaming request to /api/chat with JSON mode enabled
+// and returns the assistant message content.
+func (c *OllamaClient) chat(ctx context.Context, system, prompt string) (string, error) {
+	payload := map[string]interface{}{
+		"model": c.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": prompt},
+		},
+		"format": "json",
+		"stream": false,
+		"options": map[string]interface{}{
+			"temperature": 0.1,
+		},
+	}
+
+	var response struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		PromptEvalCount int `json:"prompt_eval_count"`
+		EvalCount       int `json:"eval_count"`
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(payload).
+		SetResult(&response).
+		Post("/api/chat")
+
+	if err != nil {
+		return "", fmt.Errorf("API request failed: %w", err)
+	}
+
+	if resp.IsError() {
+		return "", newAPIError(resp)
+	}
+	reportUsage(ctx, response.PromptEvalCount, response.EvalCount)
+
+	if response.Message.Content == "" {
+		return "", fmt.Errorf("no response from API")
+	}
+
+	return response.Message.Content, nil
 }
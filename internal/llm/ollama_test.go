@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOllamaClient_Analyze(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+
+		var body struct {
+			Model  string `json:"model"`
+			Format string `json:"format"`
+			Stream bool   `json:"stream"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "llama3", body.Model)
+		assert.Equal(t, "json", body.Format)
+		assert.False(t, body.Stream)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"message": map[string]string{
+				"role":    "assistant",
+				"content": `{"related": true, "consistent": true, "confidence": 0.8, "reason": "matches"}`,
+			},
+			"done": true,
+		})
+	}))
+	defer srv.Close()
+
+	client, err := NewOllamaClient("llama3", srv.URL, time.Second)
+	require.NoError(t, err)
+
+	result, err := client.Analyze(context.Background(), AnalyzeRequest{CodeSymbol: "CalculateShipping"})
+	require.NoError(t, err)
+	assert.True(t, result.Consistent)
+	assert.Equal(t, "matches", result.Reason)
+}
+
+func TestOllamaClient_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client, err := NewOllamaClient("llama3", srv.URL, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = client.Analyze(context.Background(), AnalyzeRequest{})
+	assert.Error(t, err)
+}
@@ -2,8 +2,8 @@ package llm
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 
@@ -18,7 +18,7 @@ type OpenAIClient struct {
 }
 
 // NewOpenAIClient 创建 OpenAI 客户端
-func NewOpenAIClient(model, apiKey, baseURL string) (*OpenAIClient, error) {
+func NewOpenAIClient(model, apiKey, baseURL string, timeout time.Duration) (*OpenAIClient, error) {
 	if baseURL == "" {
 		baseURL = "https://api.openai.com/v1"
 	}
@@ -26,7 +26,8 @@ func NewOpenAIClient(model, apiKey, baseURL string) (*OpenAIClient, error) {
 	client := resty.New().
 		SetBaseURL(baseURL).
 		SetHeader("Authorization", "Bearer "+apiKey).
-		SetHeader("Content-Type", "application/json")
+		SetHeader("Content-Type", "application/json").
+		SetTimeout(timeout)
 
 	return &OpenAIClient{
 		client:  client,
@@ -41,12 +42,51 @@ func (c *OpenAIClient) Name() string {
 
 // Analyze 执行分析
 func (c *OpenAIClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
-	prompt := buildPrompt(req)
+	content, err := c.chatCompletion(ctx, systemPrompt, buildPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckResult(content)
+}
+
+// AnalyzePR checks a documentation segment against the code before and after a change.
+func (c *OpenAIClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	prompt, err := buildPRPrompt(req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.chatCompletion(ctx, prSystemPrompt, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckResult(content)
+}
 
+// AnalyzeBlock checks a documentation block against each symbol bound to it in one request.
+func (c *OpenAIClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	content, err := c.chatCompletion(ctx, blockSystemPrompt, buildBlockPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	return parseBlockResults(content, req.Symbols)
+}
+
+// RewriteDoc asks for a corrected version of an inconsistent documentation block.
+func (c *OpenAIClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	content, err := c.chatCompletion(ctx, rewriteSystemPrompt, buildRewritePrompt(req))
+	if err != nil {
+		return "", err
+	}
+	return parseRewrite(content)
+}
+
+// chatCompletion sends a single-turn request in JSON mode and returns the
+// content of the first choice.
+func (c *OpenAIClient) chatCompletion(ctx context.Context, system, prompt string) (string, error) {
 	payload := map[string]interface{}{
 		"model": c.model,
 		"messages": []map[string]string{
-			{"role": "system", "content": systemPrompt},
+			{"role": "system", "content": system},
 			{"role": "user", "content": prompt},
 		},
 		"response_format": map[string]string{"type": "json_object"},
@@ -59,6 +99,10 @@ func (c *OpenAIClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.
 				Content string `json:"content"`
 			} `json:"message"`
 		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
 	}
 
 	resp, err := c.client.R().
@@ -68,21 +112,17 @@ func (c *OpenAIClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.
 		Post("/chat/completions")
 
 	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
+		return "", fmt.Errorf("API request failed: %w", err)
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", resp.String())
+		return "", newAPIError(resp)
 	}
+	reportUsage(ctx, response.Usage.PromptTokens, response.Usage.CompletionTokens)
 
 	if len(response.Choices) == 0 {
-		return nil, fmt.Errorf("no response from API")
-	}
-
-	var result types.CheckResult
-	if err := json.Unmarshal([]byte(response.Choices[0].Message.Content), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return "", fmt.Errorf("no response from API")
 	}
 
-	return &result, nil
+	return response.Choices[0].Message.Content, nil
 }
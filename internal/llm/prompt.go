@@ -2,6 +2,10 @@ package llm
 
 import "fmt"
 
+// PromptVersion identifies the current prompt wording. It is part of every
+// cache key, so bump it whenever a prompt changes to invalidate cached verdicts.
+const PromptVersion = "4"
+
 const systemPrompt = `You are a code-documentation consistency checker. Your task is to determine whether the given documentation description matches the code implementation.
 
 IMPORTANT: First determine if the documentation is actually describing THIS SPECIFIC code/function.
@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// BlockSymbol is the code of one symbol bound to a documentation block.
+type BlockSymbol struct {
+	CodeFile    string `json:"code_file"`
+	CodeSymbol  string `json:"code_symbol"`
+	CodeContent string `json:"code_content"`
+}
+
+// BlockAnalyzeRequest asks whether a documentation block that describes
+// several symbols together matches the code of each of them.
+type BlockAnalyzeRequest struct {
+	DocContent string        `json:"doc_content"`
+	Symbols    []BlockSymbol `json:"symbols"`
+}
+
+// blockResponse is the model output for a block request.
+type blockResponse struct {
+	Results []struct {
+		Symbol string `json:"symbol"`
+		types.CheckResult
+	} `json:"results"`
+}
+
+const blockSystemPrompt = `You are a code-documentation consistency checker. The documentation block describes several code symbols together. Determine, for each symbol, whether the documentation matches its implementation.
+
+You must output the result in JSON format:
+{"results": [{"symbol": "the symbol name exactly as given", "related": true/false, "consistent": true/false, "confidence": 0.0-1.0, "reason": "explanation for the judgment", "suggestion": "if inconsistent, a fix suggestion"}]}
+
+Guidelines:
+1. Output exactly one result per symbol, in the order the symbols are given
+2. Attribute each problem to the symbol whose code contradicts the documentation; the other symbols stay consistent
+3. If the documentation says nothing about a symbol, set related=false and consistent=true for it
+4. Statements about how the symbols work together (call order, values passed between them) must hold for the code of every symbol involved
+5. Values, thresholds, and conditions must match exactly
+6. If code implements extra functionality not in docs, that's acceptable`
+
+func buildBlockPrompt(req BlockAnalyzeRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("Please check if the following documentation matches the code of each symbol:\n\n")
+	sb.WriteString("## Documentation\n")
+	sb.WriteString(req.DocContent)
+	sb.WriteString("\n\n")
+
+	labels := blockLabels(req.Symbols)
+	for i, sym := range req.Symbols {
+		fmt.Fprintf(&sb, "## Symbol %d: %s\n", i+1, labels[i])
+		sb.WriteString("File: " + sym.CodeFile + "\n\n")
+		sb.WriteString("```go\n" + sym.CodeContent + "\n```\n\n")
+	}
+
+	sb.WriteString("Output one result per symbol in JSON format.")
+	return sb.String()
+}
+
+// blockLabels names the symbols of a block request for the model. A name
+// that several symbols share is qualified with the symbol's file, so each
+// verdict can be told apart.
+func blockLabels(symbols []BlockSymbol) []string {
+	count := make(map[string]int)
+	for _, sym := range symbols {
+		count[sym.CodeSymbol]++
+	}
+	labels := make([]string, len(symbols))
+	for i, sym := range symbols {
+		labels[i] = sym.CodeSymbol
+		if count[sym.CodeSymbol] > 1 {
+			labels[i] = fmt.Sprintf("%s (%s)", sym.CodeSymbol, sym.CodeFile)
+		}
+	}
+	return labels
+}
+
+// parseBlockResults decodes per-symbol verdicts from model output, in the
+// order of the request's symbols. Verdicts are matched by the label the
+// prompt gave the symbol, or by position when the model left the label out
+// or gave only the bare name of a symbol whose name is shared.
+func parseBlockResults(content string, symbols []BlockSymbol) ([]*types.CheckResult, error) {
+	var response blockResponse
+	if err := json.Unmarshal([]byte(extractJSON(content)), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	byName := make(map[string]types.CheckResult)
+	for _, r := range response.Results {
+		if r.Symbol != "" {
+			byName[r.Symbol] = r.CheckResult
+		}
+	}
+
+	labels := blockLabels(symbols)
+	results := make([]*types.CheckResult, len(symbols))
+	for i, sym := range symbols {
+		if r, ok := byName[labels[i]]; ok {
+			results[i] = &r
+			continue
+		}
+		if i < len(response.Results) && (response.Results[i].Symbol == "" ||
+			labels[i] != sym.CodeSymbol && response.Results[i].Symbol == sym.CodeSymbol) {
+			r := response.Results[i].CheckResult
+			results[i] = &r
+			continue
+		}
+		return nil, fmt.Errorf("response has no verdict for %s", labels[i])
+	}
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockResults(t *testing.T) {
+	symbols := []BlockSymbol{{CodeSymbol: "PlaceOrder"}, {CodeSymbol: "CalculateShipping"}}
+
+	results, err := parseBlockResults(`{"results": [
+		{"symbol": "CalculateShipping", "consistent": false, "reason": "threshold is 500"},
+		{"symbol": "PlaceOrder", "consistent": true}
+	]}`, symbols)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Consistent)
+	assert.False(t, results[1].Consistent)
+	assert.Equal(t, "threshold is 500", results[1].Reason)
+
+	results, err = parseBlockResults(`{"results": [{"consistent": true}, {"consistent": false}]}`, symbols)
+	require.NoError(t, err)
+	assert.True(t, results[0].Consistent)
+	assert.False(t, results[1].Consistent)
+
+	_, err = parseBlockResults(`{"results": [{"symbol": "PlaceOrder", "consistent": true}]}`, symbols)
+	assert.ErrorContains(t, err, "CalculateShipping")
+}
+
+func TestParseBlockResults_SharedName(t *testing.T) {
+	symbols := []BlockSymbol{
+		{CodeFile: "order/limits.go", CodeSymbol: "MaxItems"},
+		{CodeFile: "cart/limits.go", CodeSymbol: "MaxItems"},
+	}
+	assert.Contains(t, buildBlockPrompt(BlockAnalyzeRequest{Symbols: symbols}), "## Symbol 2: MaxItems (cart/limits.go)")
+
+	results, err := parseBlockResults(`{"results": [
+		{"symbol": "MaxItems (cart/limits.go)", "consistent": false},
+		{"symbol": "MaxItems (order/limits.go)", "consistent": true}
+	]}`, symbols)
+	require.NoError(t, err)
+	assert.True(t, results[0].Consistent)
+	assert.False(t, results[1].Consistent)
+
+	results, err = parseBlockResults(`{"results": [
+		{"symbol": "MaxItems", "consistent": true},
+		{"symbol": "MaxItems", "consistent": false}
+	]}`, symbols)
+	require.NoError(t, err)
+	assert.True(t, results[0].Consistent)
+	assert.False(t, results[1].Consistent)
+}
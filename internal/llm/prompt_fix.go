@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RewriteRequest asks for a corrected version of a documentation block that
+// no longer matches the code.
+type RewriteRequest struct {
+	DocFile    string `json:"doc_file"`
+	DocContent string `json:"doc_content"`
+	CodeFile   string `json:"code_file"`
+	CodeSymbol string `json:"code_symbol"`
+	// OldCode is the code before a PR change; empty in check mode.
+	OldCode string `json:"old_code,omitempty"`
+	// CodeContent is the current code of the symbol.
+	CodeContent string `json:"code_content"`
+	// Reason and Suggestion come from the consistency verdict.
+	Reason     string `json:"reason"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// RewriteResponse is the model output for a rewrite request.
+type RewriteResponse struct {
+	Content string `json:"content"`
+}
+
+const rewriteSystemPrompt = `You are a technical writer fixing documentation that no longer matches the code.
+
+You must output the result in JSON format:
+{"content": "the complete corrected documentation block"}
+
+Guidelines:
+1. Change only the statements that contradict the code; keep everything else verbatim
+2. Keep the same language, tone, Markdown structure and the first line (e.g. the heading) unchanged
+3. Do not add sections, notes about the change, or HTML comments
+4. Output the whole block, not just the changed lines`
+
+func buildRewritePrompt(req RewriteRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Documentation Block\n")
+	sb.WriteString("File: " + req.DocFile + "\n\n")
+	sb.WriteString(req.DocContent)
+	sb.WriteString("\n\n")
+
+	sb.WriteString("## Code\n")
+	sb.WriteString("File: " + req.CodeFile + "\n")
+	sb.WriteString("Symbol: " + req.CodeSymbol + "\n\n")
+	if req.OldCode != "" {
+		sb.WriteString("Before the change:\n```go\n" + req.OldCode + "\n```\n\n")
+		sb.WriteString("After the change:\n")
+	}
+	sb.WriteString("```go\n" + req.CodeContent + "\n```\n\n")
+
+	sb.WriteString("## Problem\n")
+	sb.WriteString(req.Reason + "\n")
+	if req.Suggestion != "" {
+		sb.WriteString("Suggested fix: " + req.Suggestion + "\n")
+	}
+
+	sb.WriteString("\nRewrite the documentation block so it matches the code.\n")
+	sb.WriteString("Output JSON: {\"content\": \"...\"}")
+
+	return sb.String()
+}
+
+// parseRewrite decodes the rewritten documentation from model output.
+func parseRewrite(content string) (string, error) {
+	var result RewriteResponse
+	if err := json.Unmarshal([]byte(extractJSON(content)), &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if strings.TrimSpace(result.Content) == "" {
+		return "", fmt.Errorf("empty rewrite in response")
+	}
+	return result.Content, nil
+}
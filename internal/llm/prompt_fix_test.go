@@ -0,0 +1,35 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRewritePrompt(t *testing.T) {
+	prompt := buildRewritePrompt(RewriteRequest{
+		DocFile:     "docs/pricing.md",
+		DocContent:  "## Free Shipping\n\nOrders over 100 ship free.",
+		CodeFile:    "shop/shipping.go",
+		CodeSymbol:  "CalculateShipping",
+		OldCode:     "if amount >= 100 {",
+		CodeContent: "if amount >= 500 {",
+		Reason:      "threshold changed from 100 to 500",
+	})
+
+	assert.Contains(t, prompt, "Orders over 100 ship free.")
+	assert.Contains(t, prompt, "Before the change:\n```go\nif amount >= 100 {")
+	assert.Contains(t, prompt, "```go\nif amount >= 500 {")
+	assert.Contains(t, prompt, "threshold changed from 100 to 500")
+	assert.NotContains(t, prompt, "Suggested fix")
+}
+
+func TestParseRewrite(t *testing.T) {
+	content, err := parseRewrite("```json\n{\"content\": \"## Free Shipping\\n\\nOrders over 500 ship free.\"}\n```")
+	require.NoError(t, err)
+	assert.Equal(t, "## Free Shipping\n\nOrders over 500 ship free.", content)
+
+	_, err = parseRewrite(`{"content": "  "}`)
+	assert.Error(t, err)
+}
@@ -1,18 +1,39 @@
 package llm
 
-// PRConsistencyPrompt is the prompt template for PR consistency checking.
-const PRConsistencyPrompt = `You are a code documentation consistency expert.
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+const prSystemPrompt = `You are a code-documentation consistency checker reviewing a code change. Your task is to determine whether documentation that described the code before the change is still accurate after it.
+
+You must output the result in JSON format with the following fields:
+- related: boolean, whether the documentation is specifically about the changed code
+- consistent: boolean, whether the documentation still matches the code after the change (set to true if not related)
+- confidence: number (0-1), confidence level
+- reason: string, explanation for the judgment
+- suggestion: string, if inconsistent, provide a fix suggestion
 
-## Code Change Information
+Guidelines:
+1. If the documentation is about a DIFFERENT symbol or topic, set related=false and consistent=true
+2. Only differences introduced by the change can make the documentation outdated
+3. Values, thresholds, and conditions must match the code after the change exactly
+4. If code implements extra functionality not in docs, that's acceptable`
+
+// PRConsistencyPrompt is the prompt template for PR consistency checking.
+const PRConsistencyPrompt = `## Code Change Information
 - File: {{.CodeFile}}
 - Symbol: {{.CodeSymbol}} ({{.CodeType}})
 - Change Type: {{.ChangeType}}
 
 ### Code Before Change
-{{.OldCode}}
+{{if .OldCode}}` + "```go\n{{.OldCode}}\n```" + `{{else}}(none: the symbol did not exist before this change){{end}}
 
 ### Code After Change
-{{.NewCode}}
+{{if .NewCode}}` + "```go\n{{.NewCode}}\n```" + `{{else}}(none: the symbol was removed by this change){{end}}
 
 ## Related Documentation
 - File: {{.DocFile}}
@@ -22,6 +43,8 @@ const PRConsistencyPrompt = `You are a code documentation consistency expert.
 
 ## Task
 Analyze whether the documentation is still accurate after the code change.
+Compare the code before and after the change; only differences introduced by
+the change can make previously accurate documentation outdated.
 
 Focus on:
 1. Numeric values (thresholds, limits, defaults)
@@ -29,46 +52,49 @@ Focus on:
 3. Parameter documentation
 4. Return value documentation
 
+If the documentation is not about this symbol, set related=false and consistent=true.
+
 ## Output Format
 Output JSON:
 {
+  "related": true/false,
   "consistent": true/false,
   "confidence": 0.0-1.0,
-  "reason": "Brief explanation",
+  "reason": "Brief explanation; if inconsistent, name the changed code (before -> after) that invalidates the documentation",
   "suggestion": "If inconsistent, how to fix the documentation"
 }`
 
-// PRRelevancePrompt is the prompt template for relevance determination.
-const PRRelevancePrompt = `You are a code documentation relevance expert.
-
-## Code Change
+// PRRelevancePrompt is the prompt template for filtering the documentation
+// segments relevant to a changed symbol. It shows the code before the change
+// too, since documentation of the old behavior is what may now be outdated.
+const PRRelevancePrompt = `## Code Change
 - File: {{.CodeFile}}
 - Symbol: {{.CodeSymbol}} ({{.CodeType}})
 - Change Type: {{.ChangeType}}
-- Code:
-{{.NewCode}}
 
-## Documentation Segment
-- File: {{.DocFile}}
-- Heading: {{.DocHeading}}
-- Content:
-{{.DocContent}}
+### Code Before Change
+{{if .OldCode}}` + "```go\n{{.OldCode}}\n```" + `{{else}}(none: the symbol did not exist before this change){{end}}
+
+### Code After Change
+{{if .NewCode}}` + "```go\n{{.NewCode}}\n```" + `{{else}}(none: the symbol was removed by this change){{end}}
 
+## Candidate Documentation Segments
+{{range $i, $seg := .Candidates}}
+[{{$i}}] {{$seg.File}} - {{$seg.Heading}}
+{{$seg.Content}}
+{{end}}
 ## Task
-Determine if this documentation describes the functionality of this code.
+Determine which segments describe the functionality of this code, before or
+after the change.
 
 Criteria:
-1. Does the documentation mention this function/struct/variable?
-2. Does the documentation describe related business logic?
-3. Do examples in the documentation involve this code?
+1. Does the segment mention this function/struct/variable?
+2. Does the segment describe related business logic?
+3. Do examples in the segment involve this code?
 
 ## Output Format
 Output JSON:
-{
-  "relevant": true/false,
-  "confidence": 0.0-1.0,
-  "reason": "Brief explanation"
-}`
+{"relevant": [indices of relevant segments]}`
 
 // PRPromptData contains data for PR prompt templates.
 type PRPromptData struct {
@@ -82,3 +108,65 @@ type PRPromptData struct {
 	DocHeading string
 	DocContent string
 }
+
+// PRRelevanceData contains data for PRRelevancePrompt.
+type PRRelevanceData struct {
+	PRPromptData
+	Candidates []types.DocSegment
+}
+
+// PRAnalyzeRequest asks whether a documentation segment is still accurate
+// after a code change.
+type PRAnalyzeRequest struct {
+	Segment types.DocSegment
+	Symbol  types.ChangedSymbol
+}
+
+var (
+	prConsistencyTemplate = template.Must(template.New("pr_consistency").Parse(PRConsistencyPrompt))
+	prRelevanceTemplate   = template.Must(template.New("pr_relevance").Parse(PRRelevancePrompt))
+)
+
+// newPRPromptData fills the template data from a segment and changed symbol.
+func newPRPromptData(segment types.DocSegment, symbol types.ChangedSymbol) PRPromptData {
+	return PRPromptData{
+		CodeFile:   symbol.File,
+		CodeSymbol: symbol.Name,
+		CodeType:   string(symbol.Type),
+		ChangeType: string(symbol.ChangeType),
+		OldCode:    symbol.OldCode,
+		NewCode:    symbol.NewCode,
+		DocFile:    segment.File,
+		DocHeading: segment.Heading,
+		DocContent: segment.Content,
+	}
+}
+
+// buildPRPrompt renders PRConsistencyPrompt for a request.
+func buildPRPrompt(req PRAnalyzeRequest) (string, error) {
+	var sb strings.Builder
+	if err := prConsistencyTemplate.Execute(&sb, newPRPromptData(req.Segment, req.Symbol)); err != nil {
+		return "", fmt.Errorf("failed to render PR prompt: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// buildPRRelevancePrompt renders PRRelevancePrompt for a relevance request
+// about a symbol changed by a diff.
+func buildPRRelevancePrompt(req RelevanceRequest) (string, error) {
+	data := PRRelevanceData{
+		PRPromptData: newPRPromptData(types.DocSegment{}, req.Symbol),
+		Candidates:   make([]types.DocSegment, len(req.Candidates)),
+	}
+	for i, seg := range req.Candidates {
+		// The start of a section is enough to tell what it is about.
+		seg.Content = Truncate(seg.Content, CandidateTokens)
+		data.Candidates[i] = seg
+	}
+
+	var sb strings.Builder
+	if err := prRelevanceTemplate.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render PR relevance prompt: %w", err)
+	}
+	return sb.String(), nil
+}
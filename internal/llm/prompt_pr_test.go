@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var shippingChange = PRAnalyzeRequest{
+	Segment: types.DocSegment{
+		File:    "docs/pricing.md",
+		Heading: "Free Shipping",
+		Content: "Orders over 100 ship free.",
+	},
+	Symbol: types.ChangedSymbol{
+		File:       "shop/shipping.go",
+		Name:       "CalculateShipping",
+		Type:       types.BindingFunc,
+		ChangeType: types.ChangeModified,
+		OldCode:    "func CalculateShipping(amount float64) float64 {\n\tif amount >= 100 {",
+		NewCode:    "func CalculateShipping(amount float64) float64 {\n\tif amount >= 500 {",
+	},
+}
+
+func TestBuildPRPrompt(t *testing.T) {
+	prompt, err := buildPRPrompt(shippingChange)
+	require.NoError(t, err)
+
+	assert.Contains(t, prompt, "- Symbol: CalculateShipping (func)")
+	assert.Contains(t, prompt, "- Change Type: modified")
+	assert.Contains(t, prompt, "amount >= 100")
+	assert.Contains(t, prompt, "amount >= 500")
+	assert.Contains(t, prompt, "- File: docs/pricing.md")
+	assert.Contains(t, prompt, "- Heading: Free Shipping")
+	assert.Contains(t, prompt, "Orders over 100 ship free.")
+	assert.NotContains(t, prompt, "<no value>")
+}
+
+func TestBuildPRPrompt_AddedAndDeleted(t *testing.T) {
+	added := shippingChange
+	added.Symbol.ChangeType = types.ChangeAdded
+	added.Symbol.OldCode = ""
+	prompt, err := buildPRPrompt(added)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "did not exist before this change")
+
+	deleted := shippingChange
+	deleted.Symbol.ChangeType = types.ChangeDeleted
+	deleted.Symbol.NewCode = ""
+	prompt, err = buildPRPrompt(deleted)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "was removed by this change")
+}
+
+func TestBuildRelevancePrompt_Change(t *testing.T) {
+	req := RelevanceRequest{
+		Symbol:     shippingChange.Symbol,
+		Candidates: []types.DocSegment{shippingChange.Segment, {File: "docs/returns.md", Heading: "Returns", Content: "Returns are free."}},
+	}
+	prompt, err := buildRelevancePrompt(req)
+	require.NoError(t, err)
+
+	assert.Contains(t, prompt, "- Change Type: modified")
+	assert.Contains(t, prompt, "amount >= 100")
+	assert.Contains(t, prompt, "amount >= 500")
+	assert.Contains(t, prompt, "[0] docs/pricing.md - Free Shipping\nOrders over 100 ship free.")
+	assert.Contains(t, prompt, "[1] docs/returns.md - Returns\nReturns are free.")
+	assert.NotContains(t, prompt, "<no value>")
+
+	req.Symbol.ChangeType = types.ChangeNone
+	prompt, err = buildRelevancePrompt(req)
+	require.NoError(t, err)
+	assert.NotContains(t, prompt, "Change Type")
+}
+
+func TestOpenAIClient_AnalyzePR(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+
+		var body struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Messages, 2)
+		assert.Contains(t, body.Messages[1].Content, "### Code Before Change")
+		assert.Contains(t, body.Messages[1].Content, "amount >= 100")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{
+				"message": map[string]string{
+					"content": `{"related": true, "consistent": false, "confidence": 0.95, "reason": "threshold changed from 100 to 500"}`,
+				},
+			}},
+		})
+	}))
+	defer srv.Close()
+
+	client, err := NewOpenAIClient("gpt-test", "test-key", srv.URL, 0)
+	require.NoError(t, err)
+
+	result, err := client.AnalyzePR(context.Background(), shippingChange)
+	require.NoError(t, err)
+	assert.False(t, result.Consistent)
+	assert.Equal(t, "threshold changed from 100 to 500", result.Reason)
+}
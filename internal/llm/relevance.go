@@ -2,7 +2,6 @@ package llm
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -32,7 +31,15 @@ Guidelines:
 4. A segment is NOT relevant if it describes a different but similarly named symbol
 5. When in doubt, include the segment (prefer false positives over false negatives)`
 
-func buildRelevancePrompt(req RelevanceRequest) string {
+// buildRelevancePrompt renders the relevance prompt for a request. A symbol
+// changed by a diff gets PRRelevancePrompt, which shows its code before and
+// after the change; a symbol checked as it is gets only its code.
+func buildRelevancePrompt(req RelevanceRequest) (string, error) {
+	switch req.Symbol.ChangeType {
+	case types.ChangeAdded, types.ChangeModified, types.ChangeDeleted:
+		return buildPRRelevancePrompt(req)
+	}
+
 	var sb strings.Builder
 
 	sb.WriteString("## Code Symbol\n")
@@ -46,19 +53,15 @@ func buildRelevancePrompt(req RelevanceRequest) string {
 	sb.WriteString("## Candidate Documentation Segments\n\n")
 	for i, seg := range req.Candidates {
 		sb.WriteString(fmt.Sprintf("[%d] %s - %s\n", i, seg.File, seg.Heading))
-		// Truncate content if too long
-		content := seg.Content
-		if len(content) > 500 {
-			content = content[:500] + "..."
-		}
-		sb.WriteString(content)
+		// The start of a section is enough to tell what it is about.
+		sb.WriteString(Truncate(seg.Content, CandidateTokens))
 		sb.WriteString("\n\n")
 	}
 
 	sb.WriteString("Which segments (by index) are specifically describing this code symbol?\n")
 	sb.WriteString("Output JSON: {\"relevant\": [list of indices]}")
 
-	return sb.String()
+	return sb.String(), nil
 }
 
 // CheckRelevanceBatch checks relevance of multiple document segments for a symbol in one LLM call.
@@ -67,56 +70,13 @@ func (c *OpenAIClient) CheckRelevanceBatch(ctx context.Context, req RelevanceReq
 		return nil, nil
 	}
 
-	prompt := buildRelevancePrompt(req)
-
-	payload := map[string]interface{}{
-		"model": c.model,
-		"messages": []map[string]string{
-			{"role": "system", "content": relevanceSystemPrompt},
-			{"role": "user", "content": prompt},
-		},
-		"response_format": map[string]string{"type": "json_object"},
-		"temperature":     0.1,
-	}
-
-	var response struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-	}
-
-	resp, err := c.client.R().
-		SetContext(ctx).
-		SetBody(payload).
-		SetResult(&response).
-		Post("/chat/completions")
-
+	prompt, err := buildRelevancePrompt(req)
 	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
+		return nil, err
 	}
-
-	if resp.IsError() {
-		return nil, fmt.Errorf("API error: %s", resp.String())
-	}
-
-	if len(response.Choices) == 0 {
-		return nil, fmt.Errorf("no response from API")
-	}
-
-	var result RelevanceResponse
-	if err := json.Unmarshal([]byte(response.Choices[0].Message.Content), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Validate indices
-	validIndices := make([]int, 0)
-	for _, idx := range result.RelevantIndices {
-		if idx >= 0 && idx < len(req.Candidates) {
-			validIndices = append(validIndices, idx)
-		}
+	content, err := c.chatCompletion(ctx, relevanceSystemPrompt, prompt)
+	if err != nil {
+		return nil, err
 	}
-
-	return validIndices, nil
+	return parseRelevantIndices(content, len(req.Candidates))
 }
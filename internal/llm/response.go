@@ -0,0 +1,46 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// extractJSON returns the outermost JSON object embedded in s.
+// Some providers wrap the object in Markdown fences or add a short preamble.
+func extractJSON(s string) string {
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start < 0 || end < start {
+		return s
+	}
+	return s[start : end+1]
+}
+
+// parseCheckResult decodes a consistency verdict from model output.
+func parseCheckResult(content string) (*types.CheckResult, error) {
+	var result types.CheckResult
+	if err := json.Unmarshal([]byte(extractJSON(content)), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result, nil
+}
+
+// parseRelevantIndices decodes a relevance verdict from model output and
+// drops indices outside the candidate range.
+func parseRelevantIndices(content string, numCandidates int) ([]int, error) {
+	var result RelevanceResponse
+	if err := json.Unmarshal([]byte(extractJSON(content)), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	validIndices := make([]int, 0)
+	for _, idx := range result.RelevantIndices {
+		if idx >= 0 && idx < numCandidates {
+			validIndices = append(validIndices, idx)
+		}
+	}
+	return validIndices, nil
+}
@@ -0,0 +1,220 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// ErrRetriesExhausted is returned by RetryClient when every attempt failed
+// with a retryable error.
+var ErrRetriesExhausted = errors.New("LLM retries exhausted")
+
+// APIError is a non-2xx response from an LLM provider.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay requested by the server, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Body)
+}
+
+// Retryable reports whether the request may succeed if sent again.
+func (e *APIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// newAPIError builds an APIError from a failed resty response.
+func newAPIError(resp *resty.Response) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode(),
+		Body:       resp.String(),
+		RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either as seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// RetryPolicy controls how RetryClient retries failed calls.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// RetryClient wraps a Client and retries rate-limited, server-side and
+// transport failures with exponential backoff and jitter.
+type RetryClient struct {
+	inner  Client
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryClient wraps inner with the given retry policy.
+func NewRetryClient(inner Client, policy RetryPolicy) *RetryClient {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = time.Second
+	}
+	if policy.MaxBackoff < policy.InitialBackoff {
+		policy.MaxBackoff = policy.InitialBackoff
+	}
+	return &RetryClient{
+		inner:  inner,
+		policy: policy,
+		sleep:  sleepContext,
+	}
+}
+
+func (c *RetryClient) Name() string {
+	return c.inner.Name()
+}
+
+// Analyze calls the wrapped client's Analyze, retrying transient failures.
+func (c *RetryClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
+	var result *types.CheckResult
+	err := c.do(ctx, func() error {
+		var err error
+		result, err = c.inner.Analyze(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// AnalyzePR calls the wrapped client's AnalyzePR, retrying transient failures.
+func (c *RetryClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	var result *types.CheckResult
+	err := c.do(ctx, func() error {
+		var err error
+		result, err = c.inner.AnalyzePR(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// CheckRelevanceBatch calls the wrapped client's CheckRelevanceBatch, retrying transient failures.
+func (c *RetryClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
+	var indices []int
+	err := c.do(ctx, func() error {
+		var err error
+		indices, err = c.inner.CheckRelevanceBatch(ctx, req)
+		return err
+	})
+	return indices, err
+}
+
+// AnalyzeBlock calls the wrapped client's AnalyzeBlock, retrying transient failures.
+func (c *RetryClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	var results []*types.CheckResult
+	err := c.do(ctx, func() error {
+		var err error
+		results, err = c.inner.AnalyzeBlock(ctx, req)
+		return err
+	})
+	return results, err
+}
+
+// RewriteDoc calls the wrapped client's RewriteDoc, retrying transient failures.
+func (c *RetryClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	var content string
+	err := c.do(ctx, func() error {
+		var err error
+		content, err = c.inner.RewriteDoc(ctx, req)
+		return err
+	})
+	return content, err
+}
+
+func (c *RetryClient) do(ctx context.Context, call func() error) error {
+	var lastErr error
+	for attempt := 1; attempt <= c.policy.MaxAttempts; attempt++ {
+		lastErr = call()
+		if lastErr == nil {
+			return nil
+		}
+		if !isRetryable(ctx, lastErr) {
+			return lastErr
+		}
+		if attempt == c.policy.MaxAttempts {
+			break
+		}
+		if err := c.sleep(ctx, c.backoff(attempt, lastErr)); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%w after %d attempt(s): %w", ErrRetriesExhausted, c.policy.MaxAttempts, lastErr)
+}
+
+// backoff returns the delay before the next attempt. A server-provided
+// Retry-After wins; otherwise the delay doubles per attempt with jitter.
+func (c *RetryClient) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+
+	delay := c.policy.InitialBackoff << (attempt - 1)
+	if delay <= 0 || delay > c.policy.MaxBackoff {
+		delay = c.policy.MaxBackoff
+	}
+	// Equal jitter: keep half of the delay and randomize the rest.
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// isRetryable reports whether err is a transient provider or transport failure.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
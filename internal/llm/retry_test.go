@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// flakyClient fails with the queued errors before succeeding.
+type flakyClient struct {
+	MockClient
+	errs  []error
+	calls int
+}
+
+func (c *flakyClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return nil, err
+	}
+	return &types.CheckResult{Consistent: true}, nil
+}
+
+func newTestRetryClient(inner Client, attempts int, slept *[]time.Duration) *RetryClient {
+	c := NewRetryClient(inner, RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Second, MaxBackoff: 4 * time.Second})
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		return nil
+	}
+	return c
+}
+
+func TestRetryClient_RetriesRateLimit(t *testing.T) {
+	inner := &flakyClient{errs: []error{
+		&APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 7 * time.Second},
+		&APIError{StatusCode: http.StatusServiceUnavailable},
+	}}
+	var slept []time.Duration
+	client := newTestRetryClient(inner, 3, &slept)
+
+	result, err := client.Analyze(context.Background(), AnalyzeRequest{})
+	require.NoError(t, err)
+	assert.True(t, result.Consistent)
+	assert.Equal(t, 3, inner.calls)
+	require.Len(t, slept, 2)
+	assert.Equal(t, 7*time.Second, slept[0], "Retry-After must be honored")
+	assert.GreaterOrEqual(t, slept[1], time.Second)
+	assert.LessOrEqual(t, slept[1], 2*time.Second)
+}
+
+func TestRetryClient_Exhausted(t *testing.T) {
+	inner := &flakyClient{errs: []error{
+		&APIError{StatusCode: http.StatusTooManyRequests},
+		&APIError{StatusCode: http.StatusTooManyRequests},
+	}}
+	var slept []time.Duration
+	client := newTestRetryClient(inner, 2, &slept)
+
+	_, err := client.Analyze(context.Background(), AnalyzeRequest{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRetriesExhausted))
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestRetryClient_NoRetryOnClientError(t *testing.T) {
+	inner := &flakyClient{errs: []error{&APIError{StatusCode: http.StatusUnauthorized}}}
+	var slept []time.Duration
+	client := newTestRetryClient(inner, 3, &slept)
+
+	_, err := client.Analyze(context.Background(), AnalyzeRequest{})
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRetriesExhausted))
+	assert.Equal(t, 1, inner.calls)
+	assert.Empty(t, slept)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
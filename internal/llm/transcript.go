@@ -0,0 +1,284 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/blueberrycongee/docuguard/internal/cache"
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// DefaultTranscript is the transcript location relative to the project root.
+const DefaultTranscript = ".docuguard/transcript.json"
+
+// ErrNotRecorded is returned by ReplayClient for a request the transcript
+// does not hold.
+var ErrNotRecorded = errors.New("request not recorded in transcript")
+
+// TranscriptEntry is one recorded request and the response it got.
+type TranscriptEntry struct {
+	Key      string          `json:"key"`
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// transcriptFile is the JSON layout of a transcript.
+type transcriptFile struct {
+	Entries []TranscriptEntry `json:"entries"`
+}
+
+// requestKey hashes a request together with the method it was sent to.
+// Every field of the request is part of the key, so any change to the
+// documentation or code sent to the model misses the transcript.
+func requestKey(method string, req interface{}) (string, json.RawMessage, error) {
+	data, err := marshal(req)
+	if err != nil {
+		return "", nil, err
+	}
+	return cache.Key(method, string(data)), data, nil
+}
+
+// marshal encodes v as compact JSON. Code is left unescaped so that
+// comparisons such as a >= b stay readable in the transcript.
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// loadTranscript reads the entries of a transcript file by key.
+func loadTranscript(path string) (map[string]TranscriptEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file transcriptFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript %s: %w", path, err)
+	}
+	entries := make(map[string]TranscriptEntry, len(file.Entries))
+	for _, e := range file.Entries {
+		entries[e.Key] = e
+	}
+	return entries, nil
+}
+
+// RecordingClient wraps a Client and writes every successful request and
+// response to a transcript file, which ReplayClient serves back. Entries of
+// an existing transcript are kept; a request made again replaces its entry.
+type RecordingClient struct {
+	inner Client
+	path  string
+
+	mu      sync.Mutex
+	entries map[string]TranscriptEntry
+}
+
+// NewRecordingClient wraps inner, recording to the transcript at path.
+func NewRecordingClient(inner Client, path string) (*RecordingClient, error) {
+	if path == "" {
+		path = DefaultTranscript
+	}
+	entries, err := loadTranscript(path)
+	if errors.Is(err, os.ErrNotExist) {
+		entries, err = make(map[string]TranscriptEntry), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RecordingClient{inner: inner, path: path, entries: entries}, nil
+}
+
+func (c *RecordingClient) Name() string {
+	return c.inner.Name()
+}
+
+// record adds an entry and rewrites the transcript. A call whose response
+// cannot be recorded fails, so a recorded run is never silently incomplete.
+func (c *RecordingClient) record(method string, req, resp interface{}) error {
+	key, request, err := requestKey(method, req)
+	if err != nil {
+		return fmt.Errorf("failed to record %s request: %w", method, err)
+	}
+	response, err := marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to record %s response: %w", method, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = TranscriptEntry{Key: key, Method: method, Request: request, Response: response}
+
+	// Entries are sorted so a transcript recorded twice diffs cleanly.
+	file := transcriptFile{Entries: make([]TranscriptEntry, 0, len(c.entries))}
+	for _, e := range c.entries {
+		file.Entries = append(file.Entries, e)
+	}
+	sort.Slice(file.Entries, func(i, j int) bool { return file.Entries[i].Key < file.Entries[j].Key })
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return nil
+}
+
+func (c *RecordingClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
+	result, err := c.inner.Analyze(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.record("analyze", req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *RecordingClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	result, err := c.inner.AnalyzePR(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.record("analyze_pr", req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *RecordingClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
+	indices, err := c.inner.CheckRelevanceBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.record("relevance", req, indices); err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+func (c *RecordingClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	results, err := c.inner.AnalyzeBlock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.record("analyze_block", req, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *RecordingClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	content, err := c.inner.RewriteDoc(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if err := c.record("rewrite", req, content); err != nil {
+		return "", err
+	}
+	return content, nil
+}
+
+// ReplayClient answers requests from a transcript written by
+// RecordingClient, without calling a model. A request the transcript does
+// not hold fails with ErrNotRecorded.
+type ReplayClient struct {
+	path    string
+	entries map[string]TranscriptEntry
+}
+
+// NewReplayClient loads the transcript at path.
+func NewReplayClient(path string) (*ReplayClient, error) {
+	if path == "" {
+		path = DefaultTranscript
+	}
+	entries, err := loadTranscript(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transcript: %w", err)
+	}
+	return &ReplayClient{path: path, entries: entries}, nil
+}
+
+func (c *ReplayClient) Name() string {
+	return "replay"
+}
+
+// replay decodes the recorded response to a request into resp.
+func (c *ReplayClient) replay(method string, req, resp interface{}) error {
+	key, _, err := requestKey(method, req)
+	if err != nil {
+		return err
+	}
+	entry, ok := c.entries[key]
+	if !ok || entry.Method != method {
+		return fmt.Errorf("%w: %s request %.12s in %s", ErrNotRecorded, method, key, c.path)
+	}
+	if err := json.Unmarshal(entry.Response, resp); err != nil {
+		return fmt.Errorf("failed to decode recorded %s response: %w", method, err)
+	}
+	return nil
+}
+
+func (c *ReplayClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
+	var result types.CheckResult
+	if err := c.replay("analyze", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *ReplayClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	var result types.CheckResult
+	if err := c.replay("analyze_pr", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *ReplayClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
+	var indices []int
+	if err := c.replay("relevance", req, &indices); err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+func (c *ReplayClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	var results []*types.CheckResult
+	if err := c.replay("analyze_block", req, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *ReplayClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	var content string
+	if err := c.replay("rewrite", req, &content); err != nil {
+		return "", err
+	}
+	return content, nil
+}
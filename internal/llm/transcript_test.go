@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcripts", "run.json")
+	ctx := context.Background()
+	analyze := AnalyzeRequest{DocContent: "Free over $100.", CodeContent: "if total >= 500 {", CodeSymbol: "CalculateShipping", CodeFile: "shop.go"}
+	relevance := RelevanceRequest{Symbol: types.ChangedSymbol{Name: "CalculateShipping"}, Candidates: []types.DocSegment{{Heading: "Shipping"}}}
+
+	recorder, err := NewRecordingClient(&MockClient{
+		Result:          &types.CheckResult{Consistent: false, Confidence: 0.9, Reason: "threshold is 500"},
+		RelevantIndices: []int{0},
+	}, path)
+	require.NoError(t, err)
+	_, err = recorder.Analyze(ctx, analyze)
+	require.NoError(t, err)
+
+	// A second recording keeps the entries of the first.
+	recorder, err = NewRecordingClient(&MockClient{RelevantIndices: []int{0}}, path)
+	require.NoError(t, err)
+	_, err = recorder.CheckRelevanceBatch(ctx, relevance)
+	require.NoError(t, err)
+
+	replay, err := NewReplayClient(path)
+	require.NoError(t, err)
+	assert.Equal(t, "replay", replay.Name())
+
+	result, err := replay.Analyze(ctx, analyze)
+	require.NoError(t, err)
+	assert.False(t, result.Consistent)
+	assert.Equal(t, "threshold is 500", result.Reason)
+
+	indices, err := replay.CheckRelevanceBatch(ctx, relevance)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, indices)
+
+	analyze.CodeContent = "if total >= 100 {"
+	_, err = replay.Analyze(ctx, analyze)
+	assert.ErrorIs(t, err, ErrNotRecorded)
+
+	_, err = replay.AnalyzePR(ctx, PRAnalyzeRequest{})
+	assert.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestRecordingClient_SkipsErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.json")
+	recorder, err := NewRecordingClient(&MockClient{Err: errors.New("rate limited")}, path)
+	require.NoError(t, err)
+
+	_, err = recorder.RewriteDoc(context.Background(), RewriteRequest{DocContent: "doc"})
+	assert.Error(t, err)
+	assert.NoFileExists(t, path)
+
+	_, err = NewReplayClient(path)
+	assert.Error(t, err)
+}
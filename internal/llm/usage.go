@@ -0,0 +1,200 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// ErrBudgetExceeded is returned by MeteredClient instead of calling the
+// model once the run has used its token or cost budget.
+var ErrBudgetExceeded = errors.New("LLM budget exceeded")
+
+// Price is the cost in USD of a million prompt and completion tokens.
+type Price struct {
+	Prompt     float64
+	Completion float64
+}
+
+// Limits bound the LLM usage of a run. A zero field disables its limit.
+type Limits struct {
+	MaxTokens int
+	MaxCost   float64
+}
+
+// Stage is the part of a run a request belongs to.
+type Stage string
+
+const (
+	// StageRelevance is the relevance filter of two-stage matching.
+	StageRelevance Stage = "relevance"
+	// StageConsistency is a consistency check.
+	StageConsistency Stage = "consistency"
+	// StageRewrite is a documentation rewrite.
+	StageRewrite Stage = "rewrite"
+)
+
+// Meter accumulates the token usage of a run by stage and tells when its
+// limits are reached. It is safe for concurrent use.
+type Meter struct {
+	mu     sync.Mutex
+	price  Price
+	limits Limits
+	usage  types.Usage
+}
+
+// NewMeter creates a Meter that prices tokens at price and stops at limits.
+func NewMeter(price Price, limits Limits) *Meter {
+	return &Meter{price: price, limits: limits}
+}
+
+// Usage returns the usage so far. A nil Meter has none.
+func (m *Meter) Usage() types.Usage {
+	if m == nil {
+		return types.Usage{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage
+}
+
+// Exceeded reports whether the usage has reached a limit.
+func (m *Meter) Exceeded() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := m.usage.Total()
+	return m.limits.MaxTokens > 0 && total.Tokens() >= m.limits.MaxTokens ||
+		m.limits.MaxCost > 0 && total.Cost >= m.limits.MaxCost
+}
+
+// add records the usage of one call.
+func (m *Meter) add(stage Stage, u *callUsage) {
+	u.mu.Lock()
+	usage := types.TokenUsage{
+		Calls:            u.calls,
+		PromptTokens:     u.prompt,
+		CompletionTokens: u.completion,
+		Cost:             (float64(u.prompt)*m.price.Prompt + float64(u.completion)*m.price.Completion) / 1e6,
+	}
+	u.mu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch stage {
+	case StageRelevance:
+		m.usage.Relevance.Add(usage)
+	case StageConsistency:
+		m.usage.Consistency.Add(usage)
+	case StageRewrite:
+		m.usage.Rewrite.Add(usage)
+	}
+}
+
+// usageKey is the context key of the callUsage of a metered call.
+type usageKey struct{}
+
+// callUsage collects the usage providers report while serving one metered
+// call, retried requests included.
+type callUsage struct {
+	mu         sync.Mutex
+	calls      int
+	prompt     int
+	completion int
+}
+
+// reportUsage records the tokens of a provider response on the metered call
+// ctx belongs to, if any.
+func reportUsage(ctx context.Context, prompt, completion int) {
+	u, ok := ctx.Value(usageKey{}).(*callUsage)
+	if !ok {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.calls++
+	u.prompt += prompt
+	u.completion += completion
+}
+
+// MeteredClient wraps a Client, records the tokens of every request the
+// provider answers in a Meter, and refuses further calls with
+// ErrBudgetExceeded once the meter's limits are reached. Calls already in
+// flight complete, so a run can end slightly over its budget.
+type MeteredClient struct {
+	inner Client
+	meter *Meter
+}
+
+// NewMeteredClient wraps inner, recording usage in meter.
+func NewMeteredClient(inner Client, meter *Meter) *MeteredClient {
+	return &MeteredClient{inner: inner, meter: meter}
+}
+
+func (c *MeteredClient) Name() string {
+	return c.inner.Name()
+}
+
+// begin refuses a call over budget, or returns the context that collects
+// the usage of the call.
+func (c *MeteredClient) begin(ctx context.Context) (context.Context, *callUsage, error) {
+	if c.meter.Exceeded() {
+		return nil, nil, ErrBudgetExceeded
+	}
+	u := &callUsage{}
+	return context.WithValue(ctx, usageKey{}, u), u, nil
+}
+
+// Analyze calls the wrapped client and records the usage as a consistency check.
+func (c *MeteredClient) Analyze(ctx context.Context, req AnalyzeRequest) (*types.CheckResult, error) {
+	ctx, u, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.meter.add(StageConsistency, u)
+	return c.inner.Analyze(ctx, req)
+}
+
+// AnalyzePR calls the wrapped client and records the usage as a consistency check.
+func (c *MeteredClient) AnalyzePR(ctx context.Context, req PRAnalyzeRequest) (*types.CheckResult, error) {
+	ctx, u, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.meter.add(StageConsistency, u)
+	return c.inner.AnalyzePR(ctx, req)
+}
+
+// CheckRelevanceBatch calls the wrapped client and records the usage as relevance filtering.
+func (c *MeteredClient) CheckRelevanceBatch(ctx context.Context, req RelevanceRequest) ([]int, error) {
+	ctx, u, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.meter.add(StageRelevance, u)
+	return c.inner.CheckRelevanceBatch(ctx, req)
+}
+
+// AnalyzeBlock calls the wrapped client and records the usage as a consistency check.
+func (c *MeteredClient) AnalyzeBlock(ctx context.Context, req BlockAnalyzeRequest) ([]*types.CheckResult, error) {
+	ctx, u, err := c.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.meter.add(StageConsistency, u)
+	return c.inner.AnalyzeBlock(ctx, req)
+}
+
+// RewriteDoc calls the wrapped client and records the usage as a rewrite.
+func (c *MeteredClient) RewriteDoc(ctx context.Context, req RewriteRequest) (string, error) {
+	ctx, u, err := c.begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer c.meter.add(StageRewrite, u)
+	return c.inner.RewriteDoc(ctx, req)
+}
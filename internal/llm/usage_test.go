@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// newOpenAIUsageServer answers every chat completion with reply and the
+// given token usage, counting the requests it gets.
+func newOpenAIUsageServer(t *testing.T, reply string, prompt, completion int, hits *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"content": reply}}},
+			"usage":   map[string]int{"prompt_tokens": prompt, "completion_tokens": completion},
+		})
+	}))
+}
+
+func TestMeteredClient_UsageByStage(t *testing.T) {
+	var hits int32
+	srv := newOpenAIUsageServer(t, `{"related": true, "consistent": true, "confidence": 0.9, "relevant": [0]}`, 1000, 200, &hits)
+	defer srv.Close()
+
+	inner, err := NewOpenAIClient("gpt-test", "test-key", srv.URL, 0)
+	require.NoError(t, err)
+	meter := NewMeter(Price{Prompt: 10, Completion: 30}, Limits{})
+	client := NewMeteredClient(inner, meter)
+
+	ctx := context.Background()
+	_, err = client.Analyze(ctx, AnalyzeRequest{DocContent: "doc", CodeContent: "code"})
+	require.NoError(t, err)
+	_, err = client.AnalyzePR(ctx, PRAnalyzeRequest{})
+	require.NoError(t, err)
+	_, err = client.CheckRelevanceBatch(ctx, RelevanceRequest{Candidates: []types.DocSegment{{Content: "doc"}}})
+	require.NoError(t, err)
+
+	usage := meter.Usage()
+	assert.Equal(t, types.TokenUsage{Calls: 2, PromptTokens: 2000, CompletionTokens: 400, Cost: 0.032}, usage.Consistency)
+	assert.Equal(t, types.TokenUsage{Calls: 1, PromptTokens: 1000, CompletionTokens: 200, Cost: 0.016}, usage.Relevance)
+	assert.Equal(t, types.TokenUsage{}, usage.Rewrite)
+	assert.Equal(t, 3600, usage.Total().Tokens())
+	assert.InDelta(t, 0.048, usage.Total().Cost, 1e-9)
+}
+
+func TestMeteredClient_StopsAtBudget(t *testing.T) {
+	var hits int32
+	srv := newOpenAIUsageServer(t, `{"related": true, "consistent": true, "confidence": 0.9}`, 80, 20, &hits)
+	defer srv.Close()
+
+	inner, err := NewOpenAIClient("gpt-test", "test-key", srv.URL, 0)
+	require.NoError(t, err)
+	meter := NewMeter(Price{}, Limits{MaxTokens: 150})
+	client := NewMeteredClient(inner, meter)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err = client.Analyze(ctx, AnalyzeRequest{})
+		require.NoError(t, err, "call %d is under budget", i+1)
+	}
+	assert.True(t, meter.Exceeded())
+
+	_, err = client.Analyze(ctx, AnalyzeRequest{})
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	_, err = client.RewriteDoc(ctx, RewriteRequest{})
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits), "no request is sent over budget")
+	assert.Equal(t, 2, meter.Usage().Total().Calls)
+}
+
+func TestMeteredClient_ProviderUsage(t *testing.T) {
+	srv := newAnthropicTestServer(t, `{"related": true, "consistent": true, "confidence": 0.9}`)
+	defer srv.Close()
+
+	inner, err := NewAnthropicClient("claude-test", "test-key", srv.URL+"/v1", 0)
+	require.NoError(t, err)
+	meter := NewMeter(Price{}, Limits{})
+
+	_, err = NewMeteredClient(inner, meter).Analyze(context.Background(), AnalyzeRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, types.TokenUsage{Calls: 1, PromptTokens: 120, CompletionTokens: 30}, meter.Usage().Consistency)
+
+	// Calls without a meter ignore the usage.
+	_, err = inner.Analyze(context.Background(), AnalyzeRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, meter.Usage().Total().Calls)
+}
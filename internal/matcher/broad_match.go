@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/blueberrycongee/docuguard/internal/parser"
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
@@ -13,22 +14,29 @@ var codeBlockRegex = regexp.MustCompile("(?s)```[a-z]*\n(.*?)```")
 // BroadMatch performs broad keyword-based matching to find candidate document segments.
 // It uses multiple matching strategies to avoid missing relevant documents.
 // Returns all candidates without filtering by confidence threshold.
+// Pairs excluded by a docuguard:ignore directive are skipped.
 func BroadMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []types.RelevanceResult {
 	var results []types.RelevanceResult
 
 	for _, sym := range symbols {
-		symLower := strings.ToLower(sym.Name)
-		symWords := extractKeywords(sym.Name)
+		names := searchNames(sym.Name)
+		bare := bareName(sym.Name)
+		symWords := extractKeywords(bare)
 
 		for _, seg := range segments {
+			if parser.PairSuppression(seg, sym) != nil {
+				continue
+			}
+
 			score := 0.0
 			var reasons []string
 
 			contentLower := strings.ToLower(seg.Content)
 			headingLower := strings.ToLower(seg.Heading)
 
-			// Strategy 1: Exact symbol name match (highest priority)
-			if strings.Contains(contentLower, symLower) || strings.Contains(headingLower, symLower) {
+			// Strategy 1: Exact symbol name match (highest priority).
+			// Methods match on either "Type.Method" or the bare method name.
+			if containsAny(contentLower, names) || containsAny(headingLower, names) {
 				score += 1.0
 				reasons = append(reasons, "exact name match")
 			}
@@ -36,7 +44,7 @@ func BroadMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []ty
 			// Strategy 2: Match in code blocks (high priority)
 			codeBlocks := codeBlockRegex.FindAllStringSubmatch(seg.Content, -1)
 			for _, block := range codeBlocks {
-				if len(block) > 1 && strings.Contains(strings.ToLower(block[1]), symLower) {
+				if len(block) > 1 && containsAny(strings.ToLower(block[1]), names) {
 					score += 0.8
 					reasons = append(reasons, "found in code block")
 					break
@@ -57,8 +65,8 @@ func BroadMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []ty
 			}
 
 			// Strategy 4: Partial name match (e.g., "Minimum" in "MinimumNArgs")
-			if len(sym.Name) > 5 {
-				prefix := strings.ToLower(sym.Name[:len(sym.Name)/2])
+			if len(bare) > 5 {
+				prefix := strings.ToLower(bare[:len(bare)/2])
 				if len(prefix) > 3 && strings.Contains(contentLower, prefix) {
 					score += 0.3
 					reasons = append(reasons, "partial name match")
@@ -85,6 +93,16 @@ func BroadMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []ty
 	return results
 }
 
+// containsAny reports whether s contains any of the substrings.
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
 // GroupCandidatesBySymbol groups candidates by symbol for batch LLM processing.
 func GroupCandidatesBySymbol(candidates []types.RelevanceResult) map[string][]types.RelevanceResult {
 	groups := make(map[string][]types.RelevanceResult)
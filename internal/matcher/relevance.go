@@ -3,6 +3,7 @@ package matcher
 import (
 	"strings"
 
+	"github.com/blueberrycongee/docuguard/internal/parser"
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
@@ -28,24 +29,36 @@ func extractKeywords(name string) []string {
 	return words
 }
 
+// searchNames returns the lowercase names a symbol may be referred to by in
+// documentation: the qualified "Type.Method" form and the bare method name.
+func searchNames(name string) []string {
+	names := []string{strings.ToLower(name)}
+	if recv, bare := parser.SplitQualifiedName(name); recv != "" {
+		names = append(names, strings.ToLower(bare))
+	}
+	return names
+}
+
+// bareName strips the receiver type from a "Type.Method" symbol name.
+func bareName(name string) string {
+	_, bare := parser.SplitQualifiedName(name)
+	return bare
+}
+
 // QuickMatch performs fast keyword-based matching without LLM.
+// Pairs excluded by a docuguard:ignore directive are skipped.
 func QuickMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []types.RelevanceResult {
 	var results []types.RelevanceResult
 
 	for _, sym := range symbols {
-		symWords := extractKeywords(sym.Name)
-		symWords = append(symWords, strings.ToLower(sym.Name))
+		symWords := quickKeywords(sym)
 
 		for _, seg := range segments {
-			content := strings.ToLower(seg.Content + " " + seg.Heading)
-			matchCount := 0
-
-			for _, word := range symWords {
-				if len(word) > 2 && strings.Contains(content, word) {
-					matchCount++
-				}
+			if parser.PairSuppression(seg, sym) != nil {
+				continue
 			}
 
+			matchCount := countKeywords(seg, symWords)
 			if matchCount > 0 {
 				confidence := float64(matchCount) / float64(len(symWords))
 				results = append(results, types.RelevanceResult{
@@ -61,3 +74,137 @@ func QuickMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []ty
 
 	return results
 }
+
+// Suppressed returns the keyword-matching pairs that QuickMatch and
+// BroadMatch skip because of a docuguard:ignore directive.
+func Suppressed(symbols []types.ChangedSymbol, segments []types.DocSegment) []types.SuppressedPair {
+	var pairs []types.SuppressedPair
+
+	for _, sym := range symbols {
+		symWords := quickKeywords(sym)
+
+		for _, seg := range segments {
+			s := parser.PairSuppression(seg, sym)
+			if s == nil || countKeywords(seg, symWords) == 0 {
+				continue
+			}
+			pairs = append(pairs, types.SuppressedPair{
+				DocFile:     seg.File,
+				DocLine:     seg.StartLine,
+				Heading:     seg.Heading,
+				CodeFile:    sym.File,
+				Symbol:      sym.Name,
+				Suppression: *s,
+			})
+		}
+	}
+
+	return pairs
+}
+
+// quickKeywords returns the words QuickMatch searches for a symbol.
+func quickKeywords(sym types.ChangedSymbol) []string {
+	words := extractKeywords(bareName(sym.Name))
+	return append(words, searchNames(sym.Name)...)
+}
+
+// countKeywords counts the keywords found in a segment's content or heading.
+func countKeywords(seg types.DocSegment, words []string) int {
+	content := strings.ToLower(seg.Content + " " + seg.Heading)
+	count := 0
+	for _, word := range words {
+		if len(word) > 2 && strings.Contains(content, word) {
+			count++
+		}
+	}
+	return count
+}
+
+// NameMatch pairs symbols with the segments that mention them by name as a
+// whole word. It is stricter than QuickMatch, which keeps the pair count
+// manageable when every exported declaration is checked at once.
+// Pairs excluded by a docuguard:ignore directive are skipped.
+func NameMatch(symbols []types.ChangedSymbol, segments []types.DocSegment) []types.RelevanceResult {
+	var results []types.RelevanceResult
+
+	for _, sym := range symbols {
+		for _, seg := range segments {
+			if parser.PairSuppression(seg, sym) != nil || !mentions(seg, sym.Name) {
+				continue
+			}
+			results = append(results, types.RelevanceResult{
+				Segment:    seg,
+				Symbol:     sym,
+				IsRelevant: true,
+				Confidence: 1.0,
+				Reason:     "Name match",
+			})
+		}
+	}
+
+	return results
+}
+
+// NameSuppressed returns the pairs NameMatch skips because of a
+// docuguard:ignore directive.
+func NameSuppressed(symbols []types.ChangedSymbol, segments []types.DocSegment) []types.SuppressedPair {
+	var pairs []types.SuppressedPair
+
+	for _, sym := range symbols {
+		for _, seg := range segments {
+			s := parser.PairSuppression(seg, sym)
+			if s == nil || !mentions(seg, sym.Name) {
+				continue
+			}
+			pairs = append(pairs, types.SuppressedPair{
+				DocFile:     seg.File,
+				DocLine:     seg.StartLine,
+				Heading:     seg.Heading,
+				CodeFile:    sym.File,
+				Symbol:      sym.Name,
+				Suppression: *s,
+			})
+		}
+	}
+
+	return pairs
+}
+
+// mentions reports whether a segment's heading or content contains the
+// symbol name as a whole identifier. Methods also match a call of the bare
+// method name, such as "Close(", since a bare name alone is too common.
+func mentions(seg types.DocSegment, name string) bool {
+	text := seg.Heading + "\n" + seg.Content
+	if containsWord(text, name, false) {
+		return true
+	}
+	if recv, bare := parser.SplitQualifiedName(name); recv != "" {
+		return containsWord(text, bare, true)
+	}
+	return false
+}
+
+// containsWord reports whether word occurs in text between non-identifier
+// characters, and if call is set, directly followed by an opening parenthesis.
+func containsWord(text, word string, call bool) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if start == 0 || !isIdentByte(text[start-1]) {
+			if call && end < len(text) && text[end] == '(' {
+				return true
+			}
+			if !call && (end == len(text) || !isIdentByte(text[end])) {
+				return true
+			}
+		}
+		i = start + 1
+	}
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
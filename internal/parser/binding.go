@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -14,9 +15,13 @@ var (
 	bindStartRe = regexp.MustCompile(`<!--\s*docuguard:start\s*-->`)
 	bindEndRe   = regexp.MustCompile(`<!--\s*docuguard:end\s*-->`)
 	bindCodeRe  = regexp.MustCompile(`<!--\s*docuguard:bindCode\s+path="([^"]+)"\s+(\w+)="([^"]+)"\s*-->`)
+	headingRe   = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	fenceRe     = regexp.MustCompile("^```")
 )
 
 // ExtractBindings 从 Markdown 文件中提取绑定关系
+// 一个块可以包含多个 bindCode 指令，每个指令产生一个 Binding，
+// 它们共享块内的文档内容、BlockLine 和所在章节的标题。
 func ExtractBindings(filePath string) ([]types.Binding, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -25,9 +30,12 @@ func ExtractBindings(filePath string) ([]types.Binding, error) {
 	defer file.Close()
 
 	var bindings []types.Binding
-	var currentBinding *types.Binding
+	var pending []types.Binding
 	var docContent strings.Builder
-	inBlock := false
+	var fileSuppressions, blockSuppressions []types.Suppression
+	inBlock, inFence := false, false
+	blockLine := 0
+	heading := ""
 	lineNum := 0
 
 	scanner := bufio.NewScanner(file)
@@ -35,20 +43,46 @@ func ExtractBindings(filePath string) ([]types.Binding, error) {
 		lineNum++
 		line := scanner.Text()
 
+		// 忽略指令：ignore-file 作用于整个文件，ignore 作用于所在的块
+		if s, fileWide, ok := ParseIgnore(line); ok {
+			s.File, s.Line = filePath, lineNum
+			if fileWide {
+				fileSuppressions = append(fileSuppressions, s)
+			} else if inBlock {
+				blockSuppressions = append(blockSuppressions, s)
+			}
+			continue
+		}
+
+		// 记录块外最近的章节标题，跳过代码块中的 # 行
+		if !inBlock {
+			if fenceRe.MatchString(line) {
+				inFence = !inFence
+			} else if m := headingRe.FindStringSubmatch(line); m != nil && !inFence {
+				heading = strings.TrimSpace(m[1])
+			}
+		}
+
 		// 检测块开始
 		if bindStartRe.MatchString(line) {
 			inBlock = true
+			blockLine = lineNum
 			docContent.Reset()
+			blockSuppressions = nil
+			pending = nil
 			continue
 		}
 
 		// 检测块结束
 		if bindEndRe.MatchString(line) {
-			if currentBinding != nil {
-				currentBinding.DocContent = strings.TrimSpace(docContent.String())
-				bindings = append(bindings, *currentBinding)
-				currentBinding = nil
+			content := strings.TrimSpace(docContent.String())
+			for _, b := range pending {
+				b.DocContent = content
+				b.Suppressions = blockSuppressions
+				b.DocEndLine = lineNum
+				bindings = append(bindings, b)
 			}
+			pending = nil
 			inBlock = false
 			continue
 		}
@@ -56,14 +90,16 @@ func ExtractBindings(filePath string) ([]types.Binding, error) {
 		// 在块内检测绑定声明
 		if inBlock {
 			if matches := bindCodeRe.FindStringSubmatch(line); matches != nil {
-				currentBinding = &types.Binding{
+				pending = append(pending, types.Binding{
 					DocFile:    filePath,
 					DocLine:    lineNum,
+					BlockLine:  blockLine,
+					Heading:    heading,
 					CodeFile:   matches[1],
 					CodeType:   parseBindingType(matches[2]),
 					CodeSymbol: matches[3],
-				}
-			} else if currentBinding != nil {
+				})
+			} else if len(pending) > 0 {
 				// 收集文档内容
 				docContent.WriteString(line)
 				docContent.WriteString("\n")
@@ -71,20 +107,68 @@ func ExtractBindings(filePath string) ([]types.Binding, error) {
 		}
 	}
 
+	for i := range bindings {
+		bindings[i].Suppressions = append(bindings[i].Suppressions, fileSuppressions...)
+	}
+
 	return bindings, scanner.Err()
 }
 
+// parseBindingType 解析绑定类型，未知类型按 func 处理（由 docuguard lint 报告）
 func parseBindingType(s string) types.BindingType {
+	if t, ok := ParseBindingType(s); ok {
+		return t
+	}
+	return types.BindingFunc
+}
+
+// ParseBindingType 解析 bindCode 中的绑定类型，ok 表示类型是否合法
+func ParseBindingType(s string) (types.BindingType, bool) {
 	switch strings.ToLower(s) {
 	case "func":
-		return types.BindingFunc
+		return types.BindingFunc, true
 	case "struct":
-		return types.BindingStruct
+		return types.BindingStruct, true
 	case "const":
-		return types.BindingConst
+		return types.BindingConst, true
 	case "var":
-		return types.BindingVar
+		return types.BindingVar, true
+	case "type":
+		return types.BindingNamedType, true
+	case "interface":
+		return types.BindingInterface, true
+	case "method":
+		return types.BindingMethod, true
+	case "constblock":
+		return types.BindingConstBlock, true
 	default:
-		return types.BindingFunc
+		return "", false
+	}
+}
+
+// BindingDirective 识别一行中的绑定指令：start、end 或 bindCode。
+// 对于 bindCode，同时返回 path、类型和符号；格式不正确的 bindCode 返回 ok=false。
+func BindingDirective(line string) (directive string, bind *BindCode, ok bool) {
+	switch {
+	case bindStartRe.MatchString(line):
+		return "start", nil, true
+	case bindEndRe.MatchString(line):
+		return "end", nil, true
 	}
+	if m := bindCodeRe.FindStringSubmatch(line); m != nil {
+		return "bindCode", &BindCode{Path: m[1], Kind: m[2], Symbol: m[3]}, true
+	}
+	return "", nil, false
+}
+
+// BindCode bindCode 指令的原始属性
+type BindCode struct {
+	Path   string
+	Kind   string
+	Symbol string
+}
+
+// String 返回 bindCode 指令的 Markdown 注释形式
+func (b BindCode) String() string {
+	return fmt.Sprintf(`<!-- docuguard:bindCode path="%s" %s="%s" -->`, b.Path, b.Kind, b.Symbol)
 }
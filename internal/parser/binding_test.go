@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,3 +28,48 @@ func TestExtractBindings(t *testing.T) {
 	assert.Equal(t, "CalculateDiscount", bindings[1].CodeSymbol)
 	assert.Contains(t, bindings[1].DocContent, "VIP")
 }
+
+func TestExtractBindings_MultipleInBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+	require.NoError(t, os.WriteFile(path, []byte(`# Checkout
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="shop/order.go" func="PlaceOrder" -->
+<!-- docuguard:bindCode path="shop/shipping.go" func="CalculateShipping" -->
+PlaceOrder adds shipping from CalculateShipping to the total.
+<!-- docuguard:end -->
+`), 0644))
+
+	bindings, err := ExtractBindings(path)
+	require.NoError(t, err)
+	require.Len(t, bindings, 2)
+
+	assert.Equal(t, "PlaceOrder", bindings[0].CodeSymbol)
+	assert.Equal(t, "CalculateShipping", bindings[1].CodeSymbol)
+	assert.Equal(t, 3, bindings[0].DocLine)
+	assert.Equal(t, 4, bindings[1].DocLine)
+	for _, b := range bindings {
+		assert.Equal(t, 2, b.BlockLine)
+		assert.Equal(t, 6, b.DocEndLine)
+		assert.Equal(t, "PlaceOrder adds shipping from CalculateShipping to the total.", strings.TrimSpace(b.DocContent))
+	}
+}
+
+func TestExtractBindings_Heading(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Shop\n\n## Shipping\n"+
+		"<!-- docuguard:start -->\n"+
+		"<!-- docuguard:bindCode path=\"shop/shipping.go\" func=\"CalculateShipping\" -->\n"+
+		"Orders over $100 ship free.\n"+
+		"<!-- docuguard:end -->\n\n"+
+		"## Returns\n\n```sh\n# not a heading\n```\n"+
+		"<!-- docuguard:start -->\n"+
+		"<!-- docuguard:bindCode path=\"shop/shipping.go\" func=\"CalculateShipping\" -->\n"+
+		"Return shipping is charged at the outbound rate.\n"+
+		"<!-- docuguard:end -->\n"), 0644))
+
+	bindings, err := ExtractBindings(path)
+	require.NoError(t, err)
+	require.Len(t, bindings, 2)
+	assert.Equal(t, "Shipping", bindings[0].Heading)
+	assert.Equal(t, "Returns", bindings[1].Heading)
+}
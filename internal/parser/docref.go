@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// goDocDirective binds a Go declaration to the documentation describing it.
+const goDocDirective = "//docuguard:doc"
+
+// DocRefs returns the //docuguard:doc directives in the doc comments of a
+// Go file's top-level declarations. A directive on a grouped const, var or
+// type declaration applies to every spec of the group, as //docuguard:ignore
+// does.
+func (p *GoParser) DocRefs(filePath string) ([]types.DocRef, error) {
+	file, err := parser.ParseFile(p.fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []types.DocRef
+	add := func(doc *ast.CommentGroup, name string, kind types.BindingType) {
+		for _, ref := range goDocRefs(doc) {
+			ref.CodeFile, ref.CodeSymbol, ref.CodeType = filePath, name, kind
+			ref.Line = p.fset.Position(ref.pos).Line
+			refs = append(refs, ref.DocRef)
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			add(d.Doc, QualifiedName(d), types.BindingFunc)
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			kind := types.BindingVar
+			switch d.Tok {
+			case token.CONST:
+				kind = types.BindingConst
+			case token.TYPE:
+				kind = types.BindingNamedType
+			}
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Doc, s.Name.Name, kind)
+					add(d.Doc, s.Name.Name, kind)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						add(s.Doc, name.Name, kind)
+						add(d.Doc, name.Name, kind)
+					}
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// docRef is a parsed directive and its position in the file.
+type docRef struct {
+	types.DocRef
+	pos token.Pos
+}
+
+// goDocRefs parses the //docuguard:doc directives of a doc comment. The
+// directive takes path="file.md" and heading="Section" attributes.
+func goDocRefs(doc *ast.CommentGroup) []docRef {
+	if doc == nil {
+		return nil
+	}
+	var refs []docRef
+	for _, c := range doc.List {
+		rest, found := strings.CutPrefix(c.Text, goDocDirective)
+		if !found || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		attrs := parseAttrs(rest)
+		refs = append(refs, docRef{
+			DocRef: types.DocRef{DocFile: attrs["path"], Heading: attrs["heading"]},
+			pos:    c.Pos(),
+		})
+	}
+	return refs
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestGoParser_DocRefs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shop.go")
+	require.NoError(t, os.WriteFile(path, []byte(`package shop
+
+// CalculateShipping returns the shipping fee.
+//
+//docuguard:doc path="docs/api.md" heading="Shipping Calculation"
+//docuguard:doc path="README.md" heading="Shipping"
+func CalculateShipping(total float64) float64 { return 0 }
+
+//docuguard:doc path="docs/api.md" heading="Orders"
+func (o *Order) Total() float64 { return 0 }
+
+//docuguard:doc path="docs/api.md" heading="Statuses"
+const (
+	StatusPending = iota
+	StatusPaid
+)
+
+// Order is an order.
+type Order struct{}
+
+//docuguard:documented is not a directive
+func Other() {}
+`), 0644))
+
+	refs, err := NewGoParser().DocRefs(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.DocRef{
+		{CodeFile: path, CodeSymbol: "CalculateShipping", CodeType: types.BindingFunc, Line: 5, DocFile: "docs/api.md", Heading: "Shipping Calculation"},
+		{CodeFile: path, CodeSymbol: "CalculateShipping", CodeType: types.BindingFunc, Line: 6, DocFile: "README.md", Heading: "Shipping"},
+		{CodeFile: path, CodeSymbol: "Order.Total", CodeType: types.BindingFunc, Line: 9, DocFile: "docs/api.md", Heading: "Orders"},
+		{CodeFile: path, CodeSymbol: "StatusPending", CodeType: types.BindingConst, Line: 12, DocFile: "docs/api.md", Heading: "Statuses"},
+		{CodeFile: path, CodeSymbol: "StatusPaid", CodeType: types.BindingConst, Line: 12, DocFile: "docs/api.md", Heading: "Statuses"},
+	}, refs)
+}
@@ -6,6 +6,8 @@ import (
 	"go/format"
 	"go/parser"
 	"go/token"
+	"os"
+	"strings"
 
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
@@ -23,9 +25,19 @@ func NewGoParser() *GoParser {
 }
 
 // ExtractSymbol 提取指定符号的代码
+// Methods are addressed as "Type.Method". A bare function name matches a
+// top-level function, or a method only when exactly one method has that name.
+// A constblock binding returns the whole const group verbatim, doc comments
+// included; it is found by the name of one of its constants or by the type
+// its first constant declares, as in an iota enum.
 func (p *GoParser) ExtractSymbol(filePath string, symbolName string, symbolType types.BindingType) (string, int, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", 0, err
+	}
+
 	// 解析文件
-	file, err := parser.ParseFile(p.fset, filePath, nil, parser.ParseComments)
+	file, err := parser.ParseFile(p.fset, filePath, src, parser.ParseComments)
 	if err != nil {
 		return "", 0, err
 	}
@@ -33,36 +45,48 @@ func (p *GoParser) ExtractSymbol(filePath string, symbolName string, symbolType
 	var targetNode ast.Node
 	var lineNum int
 
-	// 遍历 AST 查找目标符号
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch symbolType {
-		case types.BindingFunc:
-			if fn, ok := n.(*ast.FuncDecl); ok && fn.Name.Name == symbolName {
-				targetNode = fn
-				lineNum = p.fset.Position(fn.Pos()).Line
-				return false
-			}
-		case types.BindingStruct:
-			if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == symbolName {
-				if _, isStruct := ts.Type.(*ast.StructType); isStruct {
+	switch symbolType {
+	case types.BindingFunc, types.BindingMethod:
+		if fn := findFunc(file, symbolName, symbolType == types.BindingMethod); fn != nil {
+			targetNode = fn
+			lineNum = p.fset.Position(fn.Pos()).Line
+		}
+	case types.BindingConstBlock:
+		gd := findConstBlock(file, symbolName)
+		if gd == nil {
+			return "", 0, nil
+		}
+		start := gd.Pos()
+		if gd.Doc != nil {
+			start = gd.Doc.Pos()
+		}
+		tf := p.fset.File(gd.Pos())
+		code := string(src[tf.Offset(start):tf.Offset(gd.End())])
+		return code, p.fset.Position(gd.Pos()).Line, nil
+	default:
+		// 遍历 AST 查找目标符号
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch symbolType {
+			case types.BindingStruct, types.BindingInterface, types.BindingNamedType:
+				if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == symbolName && typeMatches(ts, symbolType) {
 					targetNode = ts
 					lineNum = p.fset.Position(ts.Pos()).Line
 					return false
 				}
-			}
-		case types.BindingConst, types.BindingVar:
-			if vs, ok := n.(*ast.ValueSpec); ok {
-				for _, name := range vs.Names {
-					if name.Name == symbolName {
-						targetNode = vs
-						lineNum = p.fset.Position(vs.Pos()).Line
-						return false
+			case types.BindingConst, types.BindingVar:
+				if vs, ok := n.(*ast.ValueSpec); ok {
+					for _, name := range vs.Names {
+						if name.Name == symbolName {
+							targetNode = vs
+							lineNum = p.fset.Position(vs.Pos()).Line
+							return false
+						}
 					}
 				}
 			}
-		}
-		return true
-	})
+			return true
+		})
+	}
 
 	if targetNode == nil {
 		return "", 0, nil
@@ -76,3 +100,179 @@ func (p *GoParser) ExtractSymbol(filePath string, symbolName string, symbolType
 
 	return buf.String(), lineNum, nil
 }
+
+// typeMatches reports whether a type declaration is of the bound kind:
+// struct and interface require that underlying type, type accepts any.
+func typeMatches(ts *ast.TypeSpec, symbolType types.BindingType) bool {
+	switch symbolType {
+	case types.BindingStruct:
+		_, ok := ts.Type.(*ast.StructType)
+		return ok
+	case types.BindingInterface:
+		_, ok := ts.Type.(*ast.InterfaceType)
+		return ok
+	default:
+		return true
+	}
+}
+
+// findConstBlock returns the top-level const declaration that declares the
+// named constant, or whose first constant has the named type.
+func findConstBlock(file *ast.File, symbolName string) *ast.GenDecl {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for i, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if i == 0 {
+				if ident, ok := vs.Type.(*ast.Ident); ok && ident.Name == symbolName {
+					return gd
+				}
+			}
+			for _, name := range vs.Names {
+				if name.Name == symbolName {
+					return gd
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FindIgnore returns the //docuguard:ignore directive on the top-level
+// declaration of a symbol, or nil if it has none.
+func (p *GoParser) FindIgnore(filePath string, symbolName string, symbolType types.BindingType) (*types.Suppression, error) {
+	file, err := parser.ParseFile(p.fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc *ast.CommentGroup
+	switch symbolType {
+	case types.BindingFunc, types.BindingMethod:
+		if fn := findFunc(file, symbolName, symbolType == types.BindingMethod); fn != nil {
+			doc = fn.Doc
+		}
+	case types.BindingConstBlock:
+		if gd := findConstBlock(file, symbolName); gd != nil {
+			doc = gd.Doc
+		}
+	default:
+		doc = findSpecDoc(file, symbolName)
+	}
+
+	reason, ok := GoIgnore(doc)
+	if !ok {
+		return nil, nil
+	}
+	return &types.Suppression{
+		Symbol: symbolName,
+		Reason: reason,
+		File:   filePath,
+		Line:   p.fset.Position(doc.Pos()).Line,
+	}, nil
+}
+
+// findSpecDoc returns the doc comment of a top-level type, const or var.
+// Ungrouped declarations carry the comment on the declaration itself.
+func findSpecDoc(file *ast.File, symbolName string) *ast.CommentGroup {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			var doc *ast.CommentGroup
+			found := false
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				doc, found = s.Doc, s.Name.Name == symbolName
+			case *ast.ValueSpec:
+				doc = s.Doc
+				for _, name := range s.Names {
+					found = found || name.Name == symbolName
+				}
+			}
+			if !found {
+				continue
+			}
+			if doc == nil && !gd.Lparen.IsValid() {
+				doc = gd.Doc
+			}
+			return doc
+		}
+	}
+	return nil
+}
+
+// findFunc looks up a function or method declaration by its qualified name.
+// With methodsOnly set, plain functions are not considered.
+func findFunc(file *ast.File, symbolName string, methodsOnly bool) *ast.FuncDecl {
+	var methods []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if methodsOnly && fn.Recv == nil {
+			continue
+		}
+		if QualifiedName(fn) == symbolName {
+			return fn
+		}
+		if fn.Recv != nil && fn.Name.Name == symbolName {
+			methods = append(methods, fn)
+		}
+	}
+
+	if len(methods) == 1 {
+		return methods[0]
+	}
+	return nil
+}
+
+// QualifiedName returns the symbol name of a function declaration:
+// "Name" for functions and "Type.Name" for methods.
+func QualifiedName(fn *ast.FuncDecl) string {
+	if recv := ReceiverType(fn); recv != "" {
+		return recv + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// SplitQualifiedName splits a "Type.Method" symbol name into its receiver
+// type and bare name. Plain names return an empty receiver.
+func SplitQualifiedName(name string) (recv, bare string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// ReceiverType returns the base type name of a method receiver, without
+// pointer or type parameters, or "" for plain functions.
+func ReceiverType(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := fn.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
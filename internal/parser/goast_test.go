@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,3 +39,75 @@ func TestGoParser_ExtractSymbol_NotFound(t *testing.T) {
 	assert.Empty(t, code)
 	assert.Equal(t, 0, line)
 }
+
+func TestGoParser_ExtractSymbol_Method(t *testing.T) {
+	p := NewGoParser()
+
+	code, _, err := p.ExtractSymbol("../../testdata/code/order.go", "Invoice.Total", types.BindingFunc)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func (i Invoice) Total()")
+
+	code, _, err = p.ExtractSymbol("../../testdata/code/order.go", "Order.Total", types.BindingFunc)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func (o *Order) Total()")
+
+	// 同名方法存在多个时，裸名无法唯一定位
+	code, _, err = p.ExtractSymbol("../../testdata/code/order.go", "Total", types.BindingFunc)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	// 唯一的方法可以用裸名定位
+	code, _, err = p.ExtractSymbol("../../testdata/code/order.go", "Refund", types.BindingFunc)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func (o *Order) Refund()")
+}
+
+func TestGoParser_ExtractSymbol_Kinds(t *testing.T) {
+	p := NewGoParser()
+	const path = "../../testdata/code/status.go"
+
+	code, _, err := p.ExtractSymbol(path, "Gateway", types.BindingInterface)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Charge(amount float64) error")
+
+	// struct 绑定只匹配结构体
+	code, _, err = p.ExtractSymbol(path, "Gateway", types.BindingStruct)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	code, _, err = p.ExtractSymbol(path, "Rates", types.BindingNamedType)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Rates map[string]float64")
+
+	code, _, err = p.ExtractSymbol(path, "Amount", types.BindingNamedType)
+	require.NoError(t, err)
+	assert.Contains(t, code, "Amount = float64")
+
+	code, _, err = p.ExtractSymbol("../../testdata/code/order.go", "Order.Refund", types.BindingMethod)
+	require.NoError(t, err)
+	assert.Contains(t, code, "func (o *Order) Refund()")
+
+	// method 绑定不匹配普通函数
+	code, _, err = p.ExtractSymbol("../../testdata/code/payment.go", "CalculateShipping", types.BindingMethod)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+}
+
+func TestGoParser_ExtractSymbol_ConstBlock(t *testing.T) {
+	p := NewGoParser()
+	const path = "../../testdata/code/status.go"
+
+	// 按枚举类型名或其中任一常量名定位整个分组
+	for _, name := range []string{"Status", "StatusRefunded"} {
+		code, line, err := p.ExtractSymbol(path, name, types.BindingConstBlock)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(code, "// 订单状态取值\nconst ("), code)
+		assert.Contains(t, code, "// StatusPaid 已支付")
+		assert.True(t, strings.HasSuffix(code, "StatusRefunded\n)"), code)
+		assert.Equal(t, 7, line)
+	}
+
+	code, _, err := p.ExtractSymbol(path, "Gateway", types.BindingConstBlock)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+}
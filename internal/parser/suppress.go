@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+var (
+	// ignoreRe matches <!-- docuguard:ignore symbol="X" reason="..." -->.
+	ignoreRe = regexp.MustCompile(`<!--\s*docuguard:ignore(?:\s+(.*?))?\s*-->`)
+	// ignoreFileRe matches <!-- docuguard:ignore-file reason="..." -->.
+	ignoreFileRe = regexp.MustCompile(`<!--\s*docuguard:ignore-file(?:\s+(.*?))?\s*-->`)
+	// attrRe matches key="value" attributes of a directive.
+	attrRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// goIgnoreDirective marks a Go declaration whose documentation is not checked.
+const goIgnoreDirective = "//docuguard:ignore"
+
+// ParseIgnore parses a docuguard:ignore or docuguard:ignore-file comment in
+// a Markdown line. fileWide reports an ignore-file directive.
+func ParseIgnore(line string) (s types.Suppression, fileWide, ok bool) {
+	if m := ignoreFileRe.FindStringSubmatch(line); m != nil {
+		attrs := parseAttrs(m[1])
+		return types.Suppression{Reason: attrs["reason"]}, true, true
+	}
+	if m := ignoreRe.FindStringSubmatch(line); m != nil {
+		attrs := parseAttrs(m[1])
+		return types.Suppression{Symbol: attrs["symbol"], Reason: attrs["reason"]}, false, true
+	}
+	return types.Suppression{}, false, false
+}
+
+func parseAttrs(s string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range attrRe.FindAllStringSubmatch(s, -1) {
+		attrs[m[1]] = m[2]
+	}
+	return attrs
+}
+
+// GoIgnore returns the //docuguard:ignore directive in a declaration's doc
+// comment. The text after the directive, or its reason="..." attribute, is
+// the reason.
+func GoIgnore(doc *ast.CommentGroup) (reason string, ok bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, c := range doc.List {
+		rest, found := strings.CutPrefix(c.Text, goIgnoreDirective)
+		if !found || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+		if attrs := parseAttrs(rest); attrs["reason"] != "" {
+			rest = attrs["reason"]
+		}
+		return rest, true
+	}
+	return "", false
+}
+
+// FindSuppression returns the first suppression that applies to symbol.
+// A suppression naming a method's bare name also applies to "Type.Method".
+func FindSuppression(suppressions []types.Suppression, symbol string) *types.Suppression {
+	_, bare := SplitQualifiedName(symbol)
+	for i, s := range suppressions {
+		if s.Symbol == "" || s.Symbol == symbol || s.Symbol == bare {
+			return &suppressions[i]
+		}
+	}
+	return nil
+}
+
+// PairSuppression returns the suppression that excludes a documentation
+// segment from being checked against a changed symbol, if any.
+func PairSuppression(segment types.DocSegment, symbol types.ChangedSymbol) *types.Suppression {
+	if symbol.Suppression != nil {
+		return symbol.Suppression
+	}
+	return FindSuppression(segment.Suppressions, symbol.Name)
+}
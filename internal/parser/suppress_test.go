@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func TestParseIgnore(t *testing.T) {
+	s, fileWide, ok := ParseIgnore(`<!-- docuguard:ignore symbol="CalculateDiscount" reason="VIP rates are negotiated" -->`)
+	require.True(t, ok)
+	assert.False(t, fileWide)
+	assert.Equal(t, "CalculateDiscount", s.Symbol)
+	assert.Equal(t, "VIP rates are negotiated", s.Reason)
+
+	s, fileWide, ok = ParseIgnore("<!--docuguard:ignore-->")
+	require.True(t, ok)
+	assert.False(t, fileWide)
+	assert.Empty(t, s.Symbol)
+
+	_, fileWide, ok = ParseIgnore(`<!-- docuguard:ignore-file reason="archived" -->`)
+	require.True(t, ok)
+	assert.True(t, fileWide)
+
+	_, _, ok = ParseIgnore("<!-- docuguard:start -->")
+	assert.False(t, ok)
+}
+
+func TestFindSuppression(t *testing.T) {
+	sups := []types.Suppression{{Symbol: "Total"}}
+	assert.NotNil(t, FindSuppression(sups, "Order.Total"), "bare method names match qualified symbols")
+	assert.NotNil(t, FindSuppression(sups, "Total"))
+	assert.Nil(t, FindSuppression(sups, "Subtotal"))
+	assert.NotNil(t, FindSuppression([]types.Suppression{{}}, "Anything"))
+}
+
+func TestExtractBindings_Suppressions(t *testing.T) {
+	doc := `<!-- docuguard:ignore-file reason="legacy" -->
+<!-- docuguard:start -->
+<!-- docuguard:bindCode path="shop.go" func="CalculateDiscount" -->
+<!-- docuguard:ignore symbol="CalculateDiscount" reason="intentional" -->
+VIP members get 20% off.
+<!-- docuguard:end -->
+`
+	path := filepath.Join(t.TempDir(), "doc.md")
+	require.NoError(t, os.WriteFile(path, []byte(doc), 0644))
+
+	bindings, err := ExtractBindings(path)
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "VIP members get 20% off.", bindings[0].DocContent)
+	require.Len(t, bindings[0].Suppressions, 2)
+	assert.Equal(t, "intentional", bindings[0].Suppressions[0].Reason)
+	assert.Equal(t, 4, bindings[0].Suppressions[0].Line)
+	assert.Equal(t, "legacy", bindings[0].Suppressions[1].Reason)
+}
+
+func TestGoParser_FindIgnore(t *testing.T) {
+	src := `package shop
+
+//docuguard:ignore the docs describe the v2 formula
+func CalculateDiscount() float64 { return 0.1 }
+
+// Rate is the tax rate.
+var Rate = 0.2
+`
+	path := filepath.Join(t.TempDir(), "shop.go")
+	require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+
+	p := NewGoParser()
+	s, err := p.FindIgnore(path, "CalculateDiscount", types.BindingFunc)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	assert.Equal(t, "the docs describe the v2 formula", s.Reason)
+	assert.Equal(t, 3, s.Line)
+
+	s, err = p.FindIgnore(path, "Rate", types.BindingVar)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
@@ -12,21 +12,42 @@ type GitHubReporter struct{}
 
 func (r *GitHubReporter) Report(w io.Writer, report *types.Report) error {
 	for _, result := range report.Results {
-		if !result.Consistent {
-			// GitHub Actions 注释格式
-			fmt.Fprintf(w, "::error file=%s,line=%d,title=文档不一致::%s\n",
+		if !result.Consistent && !result.Baselined {
+			// GitHub Actions 注释格式，按严重程度选择注释级别
+			fmt.Fprintf(w, "::%s file=%s,line=%d,title=文档不一致::%s\n",
+				annotationLevel(result.Severity),
 				result.DocLoc.File,
 				result.DocLoc.Line,
 				result.Reason)
 		}
 	}
 
+	// 基线中已不再复现的条目
+	for _, e := range report.StaleBaseline {
+		fmt.Fprintf(w, "::notice file=%s,title=基线条目已失效::%s 的已知问题不再复现，可重新生成基线\n",
+			e.DocFile, e.Symbol)
+	}
+
 	// 输出汇总
-	if report.Inconsistent > 0 {
+	if report.Blocking > 0 {
 		fmt.Fprintf(w, "::error::发现 %d 处文档与代码不一致\n", report.Inconsistent)
+	} else if report.Inconsistent > 0 {
+		fmt.Fprintf(w, "::warning::发现 %d 处疑似不一致，均低于严重程度阈值\n", report.Inconsistent)
 	} else {
 		fmt.Fprintf(w, "::notice::所有文档与代码一致 ✅\n")
 	}
 
 	return nil
 }
+
+// annotationLevel 将严重程度映射为 GitHub Actions 注释级别
+func annotationLevel(sev types.Severity) string {
+	switch sev {
+	case types.SeverityWarning:
+		return "warning"
+	case types.SeverityInfo:
+		return "notice"
+	default:
+		return "error"
+	}
+}
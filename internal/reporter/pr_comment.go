@@ -20,8 +20,17 @@ func FormatPRComment(report *types.PRReport, repoURL string) string {
 		sb.WriteString(fmt.Sprintf("- Execution time: **%d** ms\n", report.ExecutionTimeMs))
 	} else {
 		inconsistentCount := 0
+		errorCount := 0
+		skippedCount := 0
+		baselinedCount := 0
 		for _, r := range report.Results {
-			if !r.Consistent {
+			if r.Status == types.StatusError {
+				errorCount++
+			} else if r.Status == types.StatusSkipped {
+				skippedCount++
+			} else if r.Baselined {
+				baselinedCount++
+			} else if !r.Consistent {
 				inconsistentCount++
 			}
 		}
@@ -29,30 +38,50 @@ func FormatPRComment(report *types.PRReport, repoURL string) string {
 		if inconsistentCount > 0 {
 			sb.WriteString(fmt.Sprintf("Found **%d** potential documentation issue(s):\n\n", inconsistentCount))
 			sb.WriteString("### Inconsistencies\n\n")
-			sb.WriteString("| Document | Code | Issue |\n")
+			sb.WriteString("| Document | Code | Severity | Issue |\n")
+			sb.WriteString("|----------|------|----------|-------|\n")
+
+			for _, r := range report.Results {
+				if !r.Consistent && !r.Baselined && r.Status != types.StatusError {
+					docLink := formatDocLink(r.Segment.File, DocLine(r), repoURL)
+					sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s |\n",
+						docLink,
+						r.Symbol.Name,
+						r.Severity,
+						truncate(r.Reason, 50),
+					))
+				}
+			}
+			sb.WriteString("\n")
+		}
+
+		if errorCount > 0 {
+			sb.WriteString("### Not Checked\n\n")
+			sb.WriteString(fmt.Sprintf("%d check(s) failed and were not evaluated:\n\n", errorCount))
+			sb.WriteString("| Document | Code | Error |\n")
 			sb.WriteString("|----------|------|-------|\n")
 
 			for _, r := range report.Results {
-				if !r.Consistent {
+				if r.Status == types.StatusError {
 					docLink := formatDocLink(r.Segment.File, r.Segment.StartLine, repoURL)
 					sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n",
 						docLink,
 						r.Symbol.Name,
-						truncate(r.Reason, 50),
+						truncate(r.Error, 50),
 					))
 				}
 			}
 			sb.WriteString("\n")
 		}
 
-		suggestCount := len(report.Results) - inconsistentCount
+		suggestCount := len(report.Results) - inconsistentCount - errorCount - skippedCount - baselinedCount
 		if suggestCount > 0 {
 			sb.WriteString("### Suggested Review\n\n")
 			sb.WriteString("| Document | Related Code | Reason |\n")
 			sb.WriteString("|----------|--------------|--------|\n")
 
 			for _, r := range report.Results {
-				if r.Consistent {
+				if r.Consistent && r.Status != types.StatusSkipped {
 					docLink := formatDocLink(r.Segment.File, r.Segment.StartLine, repoURL)
 					sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n",
 						docLink,
@@ -63,6 +92,39 @@ func FormatPRComment(report *types.PRReport, repoURL string) string {
 			}
 			sb.WriteString("\n")
 		}
+
+		if baselinedCount > 0 {
+			sb.WriteString(fmt.Sprintf("%d known issue(s) recorded in the baseline were not reported.\n\n", baselinedCount))
+		}
+		if skippedCount > 0 {
+			sb.WriteString(fmt.Sprintf("%d pair(s) were not checked because the LLM budget was exceeded.\n\n", skippedCount))
+		}
+	}
+
+	if len(report.Suppressed) > 0 {
+		sb.WriteString("### Suppressed\n\n")
+		sb.WriteString("| Document | Code | Ignored By |\n")
+		sb.WriteString("|----------|------|------------|\n")
+		for _, s := range report.Suppressed {
+			docLink := formatDocLink(s.DocFile, s.DocLine, repoURL)
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", docLink, s.Symbol, truncate(suppressionReason(s.Suppression), 50)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.StaleBaseline) > 0 {
+		sb.WriteString("### Stale Baseline Entries\n\n")
+		sb.WriteString("These known issues no longer reproduce; run `docuguard baseline create` to refresh the baseline.\n\n")
+		sb.WriteString("| Document | Code | Recorded Issue |\n")
+		sb.WriteString("|----------|------|----------------|\n")
+		for _, e := range report.StaleBaseline {
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", e.DocFile, e.Symbol, truncate(e.Reason, 50)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if report.Usage != nil {
+		sb.WriteString(fmt.Sprintf("LLM usage: %s\n\n", FormatUsage(*report.Usage)))
 	}
 
 	sb.WriteString("---\n")
@@ -77,13 +139,16 @@ func FormatPRCommentCompact(report *types.PRReport) string {
 
 	sb.WriteString("## DocuGuard\n\n")
 
-	if report.Inconsistent == 0 {
+	if report.Inconsistent == 0 && report.Errors == 0 {
 		sb.WriteString("Documentation and code are consistent.\n")
 	} else {
 		sb.WriteString(fmt.Sprintf("Found %d inconsistency(ies)\n\n", report.Inconsistent))
+		if report.Errors > 0 {
+			sb.WriteString(fmt.Sprintf("%d check(s) could not be completed.\n\n", report.Errors))
+		}
 
 		for _, r := range report.Results {
-			if !r.Consistent {
+			if !r.Consistent && !r.Baselined && r.Status != types.StatusError {
 				sb.WriteString(fmt.Sprintf("- **%s** / `%s`: %s\n",
 					r.Segment.Heading,
 					r.Symbol.Name,
@@ -112,12 +177,21 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// DocLine returns the documentation line a result points at: the exact line
+// of a finding when known, otherwise the start of the segment.
+func DocLine(r types.PRCheckResult) int {
+	if r.Line > 0 {
+		return r.Line
+	}
+	return r.Segment.StartLine
+}
+
 // FormatDetailedResult formats a detailed result for review comments.
 func FormatDetailedResult(result types.PRCheckResult) string {
 	var sb strings.Builder
 
 	sb.WriteString("### DocuGuard: Potential Issue\n\n")
-	sb.WriteString(fmt.Sprintf("**Related Document**: %s (line %d)\n\n", result.Segment.File, result.Segment.StartLine))
+	sb.WriteString(fmt.Sprintf("**Related Document**: %s (line %d)\n\n", result.Segment.File, DocLine(result)))
 	sb.WriteString(fmt.Sprintf("**Heading**: %s\n\n", result.Segment.Heading))
 	sb.WriteString(fmt.Sprintf("**Issue**: %s\n\n", result.Reason))
 
@@ -19,6 +19,8 @@ func New(format string, color bool) Reporter {
 		return &JSONReporter{}
 	case "github-actions":
 		return &GitHubReporter{}
+	case "sarif":
+		return &SARIFReporter{}
 	default:
 		return &TextReporter{Color: color}
 	}
@@ -0,0 +1,281 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	// ruleBindingInconsistent is reported by check mode for annotated bindings.
+	ruleBindingInconsistent = "docuguard/binding-inconsistent"
+	// ruleDocOutdated is reported by PR mode for docs invalidated by a code change.
+	ruleDocOutdated = "docuguard/doc-outdated"
+)
+
+// SARIFReporter outputs findings as a SARIF 2.1.0 log for code-scanning tools.
+type SARIFReporter struct {
+	// ToolVersion is reported as the driver version when set.
+	ToolVersion string
+}
+
+// Report writes check-mode inconsistencies as a SARIF log.
+func (r *SARIFReporter) Report(w io.Writer, report *types.Report) error {
+	results := make([]sarifResult, 0)
+	for _, res := range report.Results {
+		if res.Consistent {
+			continue
+		}
+
+		result := newSARIFResult(ruleBindingInconsistent, res.Severity, res.Reason, res.Suggestion,
+			res.DocLoc.File, res.DocLoc.Line, res.DocLoc.Line)
+		result.RelatedLocations = []sarifLocation{codeLocation(res.CodeLoc.File, res.CodeLoc.Line, res.CodeLoc.Symbol)}
+		result.PartialFingerprints = fingerprint(res.DocLoc.File, res.CodeLoc.File, res.CodeLoc.Symbol)
+		if res.Baselined {
+			result.Suppressions = baselineSuppression
+		}
+		results = append(results, result)
+	}
+	return r.write(w, results)
+}
+
+// ReportPR writes PR-mode inconsistencies as a SARIF log.
+func (r *SARIFReporter) ReportPR(w io.Writer, report *types.PRReport) error {
+	results := make([]sarifResult, 0)
+	for _, res := range report.Results {
+		if res.Consistent || res.Status == types.StatusError {
+			continue
+		}
+
+		startLine, endLine := res.Segment.StartLine, res.Segment.EndLine
+		if res.Line > 0 {
+			startLine, endLine = res.Line, res.Line
+		}
+		result := newSARIFResult(ruleDocOutdated, res.Severity, res.Reason, res.Suggestion,
+			res.Segment.File, startLine, endLine)
+		result.RelatedLocations = []sarifLocation{codeLocation(res.Symbol.File, res.Symbol.StartLine, res.Symbol.Name)}
+		result.PartialFingerprints = fingerprint(res.Segment.File, res.Segment.Heading, res.Symbol.File, res.Symbol.Name)
+		if res.Baselined {
+			result.Suppressions = baselineSuppression
+		}
+		results = append(results, result)
+	}
+	return r.write(w, results)
+}
+
+func (r *SARIFReporter) write(w io.Writer, results []sarifResult) error {
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "DocuGuard",
+				InformationURI: "https://github.com/blueberrycongee/docuguard",
+				Version:        r.ToolVersion,
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// sarifRules describes every rule DocuGuard can report.
+var sarifRules = []sarifRule{
+	{
+		ID:               ruleBindingInconsistent,
+		Name:             "BindingInconsistent",
+		ShortDescription: sarifMessage{Text: "Documentation does not match the bound code"},
+		FullDescription: sarifMessage{Text: "A documentation block annotated with docuguard:bindCode " +
+			"describes behavior that differs from the current implementation of the bound symbol."},
+		HelpURI: "https://github.com/blueberrycongee/docuguard#annotation-based-check",
+	},
+	{
+		ID:               ruleDocOutdated,
+		Name:             "DocOutdated",
+		ShortDescription: sarifMessage{Text: "Documentation is outdated after a code change"},
+		FullDescription: sarifMessage{Text: "A code change in this pull request invalidates " +
+			"a documentation section that describes the changed symbol."},
+		HelpURI: "https://github.com/blueberrycongee/docuguard#pr-bot-mode",
+	},
+}
+
+// baselineSuppression marks findings recorded in the baseline file, which
+// code-scanning tools show as suppressed rather than open alerts.
+var baselineSuppression = []sarifSuppression{{
+	Kind:          "external",
+	Justification: "recorded in the DocuGuard baseline",
+}}
+
+func newSARIFResult(ruleID string, sev types.Severity, reason, suggestion, docFile string, startLine, endLine int) sarifResult {
+	result := sarifResult{
+		RuleID:    ruleID,
+		Level:     sarifLevel(sev),
+		Message:   sarifMessage{Text: reason},
+		Locations: []sarifLocation{physicalLocation(docFile, startLine, endLine)},
+	}
+
+	if suggestion != "" {
+		// SARIF requires at least one artifact change per fix; an empty insertion
+		// at the start of the section carries the suggestion without editing anything.
+		result.Fixes = []sarifFix{{
+			Description: sarifMessage{Text: suggestion},
+			ArtifactChanges: []sarifArtifactChange{{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(docFile)},
+				Replacements: []sarifReplacement{{
+					DeletedRegion: sarifRegion{StartLine: max(startLine, 1), StartColumn: 1, EndColumn: 1},
+				}},
+			}},
+		}}
+	}
+
+	return result
+}
+
+func physicalLocation(file string, startLine, endLine int) sarifLocation {
+	loc := sarifLocation{PhysicalLocation: &sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: sarifURI(file)},
+	}}
+	if startLine > 0 {
+		region := &sarifRegion{StartLine: startLine}
+		if endLine > startLine {
+			region.EndLine = endLine
+		}
+		loc.PhysicalLocation.Region = region
+	}
+	return loc
+}
+
+func codeLocation(file string, line int, symbol string) sarifLocation {
+	loc := physicalLocation(file, line, line)
+	loc.ID = 1
+	if symbol != "" {
+		loc.Message = &sarifMessage{Text: "Code symbol `" + symbol + "`"}
+		loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: symbol}}
+	}
+	return loc
+}
+
+// fingerprint identifies a finding across runs so dashboards can track it.
+func fingerprint(parts ...string) map[string]string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return map[string]string{"docuguard/v1": hex.EncodeToString(sum[:16])}
+}
+
+// sarifURI converts a file path to a repository-relative URI.
+func sarifURI(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
+
+func sarifLevel(sev types.Severity) string {
+	switch sev {
+	case types.SeverityWarning:
+		return "warning"
+	case types.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	RelatedLocations    []sarifLocation    `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Fixes               []sarifFix         `json:"fixes,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               int                    `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion sarifRegion `json:"deletedRegion"`
+}
@@ -0,0 +1,113 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+func decodeSARIF(t *testing.T, buf *bytes.Buffer) sarifLog {
+	t.Helper()
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	return log
+}
+
+func TestSARIFReporter_Report(t *testing.T) {
+	report := &types.Report{Results: []types.CheckResult{
+		{
+			Consistent: false,
+			Reason:     "docs say 100, code uses 500",
+			Suggestion: "Update the threshold to 500",
+			Severity:   types.SeverityWarning,
+			DocLoc:     types.Location{File: "./docs/pricing.md", Line: 12},
+			CodeLoc:    types.Location{File: "shop/shipping.go", Line: 4, Symbol: "CalculateShipping"},
+		},
+		{Consistent: true, DocLoc: types.Location{File: "docs/pricing.md", Line: 30}},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&SARIFReporter{ToolVersion: "1.2.3"}).Report(&buf, report))
+
+	log := decodeSARIF(t, &buf)
+	assert.Equal(t, "2.1.0", log.Version)
+	run := log.Runs[0]
+	assert.Equal(t, "1.2.3", run.Tool.Driver.Version)
+	assert.Len(t, run.Tool.Driver.Rules, 2)
+	require.Len(t, run.Results, 1, "consistent results are not findings")
+
+	res := run.Results[0]
+	assert.Equal(t, ruleBindingInconsistent, res.RuleID)
+	assert.Equal(t, "warning", res.Level)
+	assert.Equal(t, "docs say 100, code uses 500", res.Message.Text)
+	assert.Equal(t, "docs/pricing.md", res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 12, res.Locations[0].PhysicalLocation.Region.StartLine)
+	require.Len(t, res.RelatedLocations, 1)
+	assert.Equal(t, "shop/shipping.go", res.RelatedLocations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "CalculateShipping", res.RelatedLocations[0].LogicalLocations[0].FullyQualifiedName)
+	require.Len(t, res.Fixes, 1)
+	assert.Equal(t, "Update the threshold to 500", res.Fixes[0].Description.Text)
+	assert.NotEmpty(t, res.PartialFingerprints)
+}
+
+func TestSARIFReporter_ReportPR(t *testing.T) {
+	report := &types.PRReport{Results: []types.PRCheckResult{
+		{
+			Segment:  types.DocSegment{File: "README.md", StartLine: 10, EndLine: 20, Heading: "Shipping"},
+			Symbol:   types.ChangedSymbol{File: "shop/shipping.go", Name: "CalculateShipping", StartLine: 3},
+			Reason:   "threshold changed",
+			Severity: types.SeverityError,
+			Status:   types.StatusChecked,
+		},
+		{
+			Segment: types.DocSegment{File: "README.md", StartLine: 40, EndLine: 50},
+			Status:  types.StatusError,
+		},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&SARIFReporter{}).ReportPR(&buf, report))
+
+	run := decodeSARIF(t, &buf).Runs[0]
+	assert.Empty(t, run.Tool.Driver.Version)
+	require.Len(t, run.Results, 1, "failed checks are not findings")
+
+	res := run.Results[0]
+	assert.Equal(t, ruleDocOutdated, res.RuleID)
+	assert.Equal(t, "error", res.Level)
+	region := res.Locations[0].PhysicalLocation.Region
+	assert.Equal(t, 10, region.StartLine)
+	assert.Equal(t, 20, region.EndLine)
+	assert.Empty(t, res.Fixes)
+}
+
+func TestSARIFReporter_EmptyResults(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&SARIFReporter{}).Report(&buf, &types.Report{}))
+	assert.Contains(t, buf.String(), `"results": []`, "SARIF requires a results array even when empty")
+}
+
+func TestSARIFReporter_BaselinedSuppressed(t *testing.T) {
+	report := &types.Report{Results: []types.CheckResult{
+		{
+			Reason:    "docs say 100, code uses 500",
+			Baselined: true,
+			DocLoc:    types.Location{File: "docs/pricing.md", Line: 12},
+			CodeLoc:   types.Location{File: "shop/shipping.go", Line: 4, Symbol: "CalculateShipping"},
+		},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&SARIFReporter{}).Report(&buf, report))
+
+	res := decodeSARIF(t, &buf).Runs[0].Results
+	require.Len(t, res, 1, "baselined findings stay in the log as suppressed results")
+	require.Len(t, res[0].Suppressions, 1)
+	assert.Equal(t, "external", res[0].Suppressions[0].Kind)
+}
@@ -29,14 +29,26 @@ func (r *TextReporter) Report(w io.Writer, report *types.Report) error {
 
 	for i, result := range report.Results {
 		status := green("[PASS]")
-		if !result.Consistent {
-			status = red("[FAIL]")
+		if result.Baselined {
+			status = "[BASELINE]"
+		} else if !result.Consistent {
+			switch result.Severity {
+			case types.SeverityWarning:
+				status = yellow("[WARN]")
+			case types.SeverityInfo:
+				status = "[INFO]"
+			default:
+				status = red("[FAIL]")
+			}
 		}
 
 		fmt.Fprintf(w, "[%d] %s\n", i+1, status)
 		fmt.Fprintf(w, "  Doc:        %s:%d\n", result.DocLoc.File, result.DocLoc.Line)
 		fmt.Fprintf(w, "  Code:       %s:%d\n", result.CodeLoc.File, result.CodeLoc.Line)
 		fmt.Fprintf(w, "  Confidence: %.0f%%\n", result.Confidence*100)
+		if !result.Consistent && result.Severity != "" {
+			fmt.Fprintf(w, "  Severity:   %s\n", result.Severity)
+		}
 		fmt.Fprintf(w, "  Reason:     %s\n", result.Reason)
 
 		if result.Suggestion != "" {
@@ -45,12 +57,50 @@ func (r *TextReporter) Report(w io.Writer, report *types.Report) error {
 		fmt.Fprintln(w)
 	}
 
+	if len(report.Suppressed) > 0 {
+		fmt.Fprintf(w, "Suppressed by docuguard:ignore:\n")
+		for _, s := range report.Suppressed {
+			fmt.Fprintf(w, "  - %s:%d -> %s (%s)\n", s.DocFile, s.DocLine, s.Symbol, suppressionReason(s.Suppression))
+		}
+		fmt.Fprintln(w)
+	}
+
 	fmt.Fprintf(w, "========================================\n")
-	fmt.Fprintf(w, "Summary: %d bindings, %s passed, %s failed\n",
+	fmt.Fprintf(w, "Summary: %d bindings, %s passed, %s failed (%d blocking)\n",
 		report.TotalBindings,
 		green(fmt.Sprintf("%d", report.Consistent)),
-		red(fmt.Sprintf("%d", report.Inconsistent)))
+		red(fmt.Sprintf("%d", report.Inconsistent)),
+		report.Blocking)
+	if report.Baselined > 0 {
+		fmt.Fprintf(w, "Baselined: %d known finding(s) not counted\n", report.Baselined)
+	}
+	if report.Skipped > 0 {
+		fmt.Fprintf(w, "Skipped: %d binding(s) not checked, LLM budget exceeded\n", report.Skipped)
+	}
+	if len(report.Failures) > 0 {
+		fmt.Fprintf(w, "Errors: %d binding(s) could not be checked:\n", len(report.Failures))
+		for _, f := range report.Failures {
+			fmt.Fprintf(w, "  - %s\n", f)
+		}
+	}
+	if len(report.StaleBaseline) > 0 {
+		fmt.Fprintf(w, "Stale baseline entries (no longer reproduce):\n")
+		for _, e := range report.StaleBaseline {
+			fmt.Fprintf(w, "  - %s: %s (%s)\n", e.DocFile, e.Symbol, e.Reason)
+		}
+	}
+	if report.Usage != nil {
+		fmt.Fprintf(w, "LLM usage: %s\n", FormatUsage(*report.Usage))
+	}
 	fmt.Fprintf(w, "Time: %dms\n\n", report.ExecutionTimeMs)
 
 	return nil
 }
+
+// suppressionReason describes where a suppression comes from and why.
+func suppressionReason(s types.Suppression) string {
+	if s.Reason == "" {
+		return fmt.Sprintf("%s:%d", s.File, s.Line)
+	}
+	return fmt.Sprintf("%s:%d, %s", s.File, s.Line, s.Reason)
+}
@@ -0,0 +1,34 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blueberrycongee/docuguard/pkg/types"
+)
+
+// FormatUsage summarizes LLM usage on one line, such as
+// "12 calls, 9800 tokens (relevance 2100, consistency 7700), est. $0.3120".
+// The cost is left out when no price is configured.
+func FormatUsage(u types.Usage) string {
+	total := u.Total()
+	var stages []string
+	for _, s := range []struct {
+		name  string
+		usage types.TokenUsage
+	}{
+		{"relevance", u.Relevance},
+		{"consistency", u.Consistency},
+		{"rewrite", u.Rewrite},
+	} {
+		if s.usage.Calls > 0 {
+			stages = append(stages, fmt.Sprintf("%s %d", s.name, s.usage.Tokens()))
+		}
+	}
+
+	line := fmt.Sprintf("%d calls, %d tokens (%s)", total.Calls, total.Tokens(), strings.Join(stages, ", "))
+	if total.Cost > 0 {
+		line += fmt.Sprintf(", est. $%.4f", total.Cost)
+	}
+	return line
+}
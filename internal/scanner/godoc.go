@@ -6,6 +6,7 @@ import (
 	"go/token"
 	"strings"
 
+	goparser "github.com/blueberrycongee/docuguard/internal/parser"
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
@@ -16,60 +17,7 @@ func ScanGoDoc(filePath string) ([]types.DocSegment, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	var segments []types.DocSegment
-
-	if file.Doc != nil {
-		seg := types.DocSegment{
-			File:      filePath,
-			StartLine: fset.Position(file.Doc.Pos()).Line,
-			EndLine:   fset.Position(file.Doc.End()).Line,
-			Heading:   "Package " + file.Name.Name,
-			Content:   file.Doc.Text(),
-			Type:      "godoc",
-			Level:     1,
-		}
-		segments = append(segments, seg)
-	}
-
-	for _, decl := range file.Decls {
-		switch d := decl.(type) {
-		case *ast.FuncDecl:
-			if d.Doc != nil {
-				seg := types.DocSegment{
-					File:      filePath,
-					StartLine: fset.Position(d.Doc.Pos()).Line,
-					EndLine:   fset.Position(d.Doc.End()).Line,
-					Heading:   "func " + d.Name.Name,
-					Content:   d.Doc.Text(),
-					Type:      "godoc",
-					Level:     2,
-				}
-				segments = append(segments, seg)
-			}
-
-		case *ast.GenDecl:
-			if d.Doc != nil {
-				for _, spec := range d.Specs {
-					switch s := spec.(type) {
-					case *ast.TypeSpec:
-						seg := types.DocSegment{
-							File:      filePath,
-							StartLine: fset.Position(d.Doc.Pos()).Line,
-							EndLine:   fset.Position(d.Doc.End()).Line,
-							Heading:   "type " + s.Name.Name,
-							Content:   d.Doc.Text(),
-							Type:      "godoc",
-							Level:     2,
-						}
-						segments = append(segments, seg)
-					}
-				}
-			}
-		}
-	}
-
-	return segments, nil
+	return extractDocSegments(fset, filePath, file), nil
 }
 
 // ScanGoDocDir scans a directory for Go documentation comments.
@@ -95,53 +43,47 @@ func ScanGoDocDir(dir string) ([]types.DocSegment, error) {
 	return segments, nil
 }
 
+// extractDocSegments returns the package comment and the doc comments of
+// top-level declarations. Headings name the declaration, such as
+// "func Client.Close" or "const MaxRetries". Specs of a grouped declaration
+// use their own comment, falling back to the one on the group.
 func extractDocSegments(fset *token.FileSet, filePath string, file *ast.File) []types.DocSegment {
 	var segments []types.DocSegment
-
-	if file.Doc != nil {
-		seg := types.DocSegment{
+	add := func(doc *ast.CommentGroup, heading string, level int) {
+		segments = append(segments, types.DocSegment{
 			File:      filePath,
-			StartLine: fset.Position(file.Doc.Pos()).Line,
-			EndLine:   fset.Position(file.Doc.End()).Line,
-			Heading:   "Package " + file.Name.Name,
-			Content:   file.Doc.Text(),
+			StartLine: fset.Position(doc.Pos()).Line,
+			EndLine:   fset.Position(doc.End()).Line,
+			Heading:   heading,
+			Content:   doc.Text(),
 			Type:      "godoc",
-			Level:     1,
-		}
-		segments = append(segments, seg)
+			Level:     level,
+		})
+	}
+
+	if file.Doc != nil {
+		add(file.Doc, "Package "+file.Name.Name, 1)
 	}
 
 	for _, decl := range file.Decls {
 		switch d := decl.(type) {
 		case *ast.FuncDecl:
 			if d.Doc != nil {
-				seg := types.DocSegment{
-					File:      filePath,
-					StartLine: fset.Position(d.Doc.Pos()).Line,
-					EndLine:   fset.Position(d.Doc.End()).Line,
-					Heading:   "func " + d.Name.Name,
-					Content:   d.Doc.Text(),
-					Type:      "godoc",
-					Level:     2,
-				}
-				segments = append(segments, seg)
+				add(d.Doc, "func "+goparser.QualifiedName(d), 2)
 			}
 
 		case *ast.GenDecl:
-			if d.Doc != nil {
-				for _, spec := range d.Specs {
-					switch s := spec.(type) {
-					case *ast.TypeSpec:
-						seg := types.DocSegment{
-							File:      filePath,
-							StartLine: fset.Position(d.Doc.Pos()).Line,
-							EndLine:   fset.Position(d.Doc.End()).Line,
-							Heading:   "type " + s.Name.Name,
-							Content:   d.Doc.Text(),
-							Type:      "godoc",
-							Level:     2,
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if doc := specDoc(s.Doc, d.Doc); doc != nil {
+						add(doc, "type "+s.Name.Name, 2)
+					}
+				case *ast.ValueSpec:
+					if doc := specDoc(s.Doc, d.Doc); doc != nil {
+						for _, n := range s.Names {
+							add(doc, d.Tok.String()+" "+n.Name, 2)
 						}
-						segments = append(segments, seg)
 					}
 				}
 			}
@@ -150,3 +92,11 @@ func extractDocSegments(fset *token.FileSet, filePath string, file *ast.File) []
 
 	return segments
 }
+
+// specDoc returns the comment of a spec, or of its declaration if it has none.
+func specDoc(spec, decl *ast.CommentGroup) *ast.CommentGroup {
+	if spec != nil {
+		return spec
+	}
+	return decl
+}
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/blueberrycongee/docuguard/internal/glob"
+	"github.com/blueberrycongee/docuguard/internal/parser"
 	"github.com/blueberrycongee/docuguard/pkg/types"
 )
 
@@ -29,6 +31,7 @@ func ScanMarkdown(filePath string) ([]types.DocSegment, error) {
 	var segments []types.DocSegment
 	var currentSegment *types.DocSegment
 	var contentBuilder strings.Builder
+	var fileSuppressions []types.Suppression
 	inCodeBlock := false
 	lineNum := 0
 
@@ -54,6 +57,16 @@ func ScanMarkdown(filePath string) ([]types.DocSegment, error) {
 			continue
 		}
 
+		// docuguard:ignore applies to the enclosing section, ignore-file to all of them.
+		if s, fileWide, ok := parser.ParseIgnore(line); ok {
+			s.File, s.Line = filePath, lineNum
+			if fileWide {
+				fileSuppressions = append(fileSuppressions, s)
+			} else if currentSegment != nil {
+				currentSegment.Suppressions = append(currentSegment.Suppressions, s)
+			}
+		}
+
 		if matches := headingRegex.FindStringSubmatch(line); matches != nil {
 			if currentSegment != nil {
 				currentSegment.Content = strings.TrimSpace(contentBuilder.String())
@@ -92,58 +105,54 @@ func ScanMarkdown(filePath string) ([]types.DocSegment, error) {
 		}
 	}
 
+	for i := range segments {
+		segments[i].Suppressions = append(segments[i].Suppressions, fileSuppressions...)
+	}
+
 	return segments, scanner.Err()
 }
 
 // ScanMarkdownDir scans a directory for Markdown files matching the patterns.
-func ScanMarkdownDir(rootDir string, patterns []string) ([]types.DocSegment, error) {
-	var allSegments []types.DocSegment
+func ScanMarkdownDir(rootDir string, patterns []string, opts glob.Options) ([]types.DocSegment, error) {
+	files, err := FindMarkdownFiles(rootDir, patterns, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, pattern := range patterns {
-		matches, err := filepath.Glob(filepath.Join(rootDir, pattern))
+	var allSegments []types.DocSegment
+	for _, file := range files {
+		segments, err := ScanMarkdown(file)
 		if err != nil {
 			continue
 		}
+		allSegments = append(allSegments, segments...)
+	}
 
-		for _, match := range matches {
-			info, err := os.Stat(match)
-			if err != nil || info.IsDir() {
-				continue
-			}
+	return allSegments, nil
+}
 
-			if strings.HasSuffix(strings.ToLower(match), ".md") {
-				segments, err := ScanMarkdown(match)
-				if err != nil {
-					continue
-				}
-				allSegments = append(allSegments, segments...)
-			}
-		}
+// FindMarkdownFiles returns the Markdown files under rootDir selected by the
+// patterns. A file named directly by a pattern is kept whatever its extension.
+func FindMarkdownFiles(rootDir string, patterns []string, opts glob.Options) ([]string, error) {
+	files, err := glob.Files(rootDir, patterns, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Handle non-glob patterns (direct file paths).
+	literal := make(map[string]bool)
 	for _, pattern := range patterns {
-		if !strings.Contains(pattern, "*") {
-			filePath := filepath.Join(rootDir, pattern)
-			if _, err := os.Stat(filePath); err == nil {
-				segments, err := ScanMarkdown(filePath)
-				if err == nil {
-					exists := false
-					for _, s := range allSegments {
-						if s.File == filePath {
-							exists = true
-							break
-						}
-					}
-					if !exists {
-						allSegments = append(allSegments, segments...)
-					}
-				}
-			}
+		if !glob.HasMeta(pattern) {
+			literal[filepath.Join(rootDir, pattern)] = true
 		}
 	}
 
-	return allSegments, nil
+	var markdown []string
+	for _, file := range files {
+		if literal[file] || strings.HasSuffix(strings.ToLower(file), ".md") {
+			markdown = append(markdown, file)
+		}
+	}
+	return markdown, nil
 }
 
 // FilterRelevantSegments filters segments that may be relevant to the symbols.
@@ -154,13 +163,14 @@ func FilterRelevantSegments(segments []types.DocSegment, symbols []types.Changed
 	for _, seg := range segments {
 		content := strings.ToLower(seg.Content + " " + seg.Heading)
 		for _, sym := range symbols {
-			symLower := strings.ToLower(sym.Name)
-			if strings.Contains(content, symLower) {
+			// Methods are named "Type.Method"; docs may use either form.
+			_, bare := parser.SplitQualifiedName(sym.Name)
+			if strings.Contains(content, strings.ToLower(sym.Name)) || strings.Contains(content, strings.ToLower(bare)) {
 				relevant = append(relevant, seg)
 				break
 			}
 
-			words := splitCamelCase(sym.Name)
+			words := splitCamelCase(bare)
 			for _, word := range words {
 				if len(word) > 3 && strings.Contains(content, strings.ToLower(word)) {
 					relevant = append(relevant, seg)
@@ -0,0 +1,15 @@
+package types
+
+// BaselineEntry identifies a known finding recorded in a baseline file.
+type BaselineEntry struct {
+	// Fingerprint hashes the doc file, heading, symbol and normalized reason.
+	Fingerprint string `json:"fingerprint"`
+	// DocFile is the documentation file of the finding.
+	DocFile string `json:"doc_file"`
+	// Heading is the documentation section heading, empty in check mode.
+	Heading string `json:"heading,omitempty"`
+	// Symbol is the code symbol the documentation was checked against.
+	Symbol string `json:"symbol"`
+	// Reason is the reason reported when the baseline was created.
+	Reason string `json:"reason"`
+}
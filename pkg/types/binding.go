@@ -4,18 +4,25 @@ package types
 type BindingType string
 
 const (
-	BindingFunc   BindingType = "func"   // 函数
-	BindingStruct BindingType = "struct" // 结构体
-	BindingConst  BindingType = "const"  // 常量
-	BindingVar    BindingType = "var"    // 变量
+	BindingFunc       BindingType = "func"       // 函数
+	BindingStruct     BindingType = "struct"     // 结构体
+	BindingConst      BindingType = "const"      // 常量
+	BindingVar        BindingType = "var"        // 变量
+	BindingNamedType  BindingType = "type"       // 任意具名类型，包括类型别名
+	BindingInterface  BindingType = "interface"  // 接口
+	BindingMethod     BindingType = "method"     // 方法，形如 Type.Method
+	BindingConstBlock BindingType = "constblock" // 整个 const (...) 分组，如 iota 枚举
 )
 
 // Binding 表示文档与代码的绑定关系
 type Binding struct {
 	// 文档位置
 	DocFile    string `json:"doc_file"`
-	DocLine    int    `json:"doc_line"`
+	DocLine    int    `json:"doc_line"`     // bindCode 所在行
+	BlockLine  int    `json:"block_line"`   // docuguard:start 所在行，同一块内的绑定共享
+	DocEndLine int    `json:"doc_end_line"` // docuguard:end 所在行
 	DocContent string `json:"doc_content"`
+	Heading    string `json:"heading,omitempty"` // 块所在章节的标题
 
 	// 代码位置
 	CodeFile   string      `json:"code_file"`
@@ -24,6 +31,24 @@ type Binding struct {
 
 	// 解析后的代码内容
 	CodeContent string `json:"code_content,omitempty"`
+
+	// 块内及文件级的 docuguard:ignore 指令
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+
+	// Go 声明注释中 //docuguard:doc 指令声明的反向绑定所指向的章节，
+	// 此时 DocLine、BlockLine 为章节标题所在行，DocEndLine 为章节最后一行
+	Segment *DocSegment `json:"segment,omitempty"`
+}
+
+// DocRef 表示 Go 声明注释中的 //docuguard:doc 指令，即从代码指向文档的反向绑定
+type DocRef struct {
+	CodeFile   string      `json:"code_file"`
+	CodeSymbol string      `json:"code_symbol"`
+	CodeType   BindingType `json:"code_type"`
+	Line       int         `json:"line"` // 指令所在行
+
+	DocFile string `json:"doc_file"` // path 属性
+	Heading string `json:"heading"`  // heading 属性
 }
 
 // Location 位置信息
@@ -11,6 +11,8 @@ const (
 	ChangeModified ChangeType = "modified"
 	// ChangeDeleted indicates a deleted symbol.
 	ChangeDeleted ChangeType = "deleted"
+	// ChangeNone marks a symbol checked as it is, outside of any diff.
+	ChangeNone ChangeType = "unchanged"
 )
 
 // ChangedSymbol represents a code symbol that has been changed.
@@ -19,18 +21,21 @@ type ChangedSymbol struct {
 	File string `json:"file"`
 	// Name is the symbol name (function, struct, etc.).
 	Name string `json:"name"`
-	// Type is the symbol type (func, struct, const, var).
+	// Type is the symbol type (func, struct, interface, type, const, var).
 	Type BindingType `json:"type"`
 	// OldCode is the code before the change.
 	OldCode string `json:"old_code"`
 	// NewCode is the code after the change.
 	NewCode string `json:"new_code"`
-	// ChangeType indicates whether the symbol was added, modified, or deleted.
+	// ChangeType indicates whether the symbol was added, modified, deleted,
+	// or, when auditing, not changed at all.
 	ChangeType ChangeType `json:"change_type"`
 	// StartLine is the starting line number of the symbol.
 	StartLine int `json:"start_line"`
 	// EndLine is the ending line number of the symbol.
 	EndLine int `json:"end_line"`
+	// Suppression is the //docuguard:ignore directive on the declaration, if any.
+	Suppression *Suppression `json:"suppression,omitempty"`
 }
 
 // FileDiff represents diff information for a single file.
@@ -47,6 +52,10 @@ type FileDiff struct {
 	AddedLines []string `json:"added_lines,omitempty"`
 	// RemovedLines contains the actual removed line content from diff.
 	RemovedLines []string `json:"removed_lines,omitempty"`
+	// AddedLineNumbers contains the new-file line number of each added line.
+	AddedLineNumbers []int `json:"added_line_numbers,omitempty"`
+	// RemovedLineNumbers contains the old-file line number of each removed line.
+	RemovedLineNumbers []int `json:"removed_line_numbers,omitempty"`
 }
 
 // LineChange represents a hunk of changed lines in a diff.
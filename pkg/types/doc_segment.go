@@ -16,6 +16,9 @@ type DocSegment struct {
 	Type string `json:"type"`
 	// Level is the heading level (1-6 for markdown).
 	Level int `json:"level"`
+	// Suppressions are the docuguard:ignore directives in the section,
+	// including file-wide ones.
+	Suppressions []Suppression `json:"suppressions,omitempty"`
 }
 
 // RelevanceResult represents the result of a relevance check
@@ -43,8 +46,24 @@ type PRReport struct {
 	RelevantPairs int `json:"relevant_pairs"`
 	// Inconsistent is the number of inconsistencies found.
 	Inconsistent int `json:"inconsistent"`
+	// Errors is the number of pairs whose check could not be completed.
+	Errors int `json:"errors"`
+	// Skipped is the number of pairs not checked because the LLM budget ran
+	// out, counting candidates the relevance filter could not check.
+	Skipped int `json:"skipped"`
+	// Blocking is the number of inconsistencies at or above the severity threshold.
+	Blocking int `json:"blocking"`
+	// Baselined is the number of inconsistencies suppressed by the baseline file.
+	Baselined int `json:"baselined"`
 	// Results contains the individual check results.
 	Results []PRCheckResult `json:"results"`
+	// StaleBaseline lists baseline entries whose doc/code pair was checked
+	// but no longer reproduces the finding.
+	StaleBaseline []BaselineEntry `json:"stale_baseline,omitempty"`
+	// Suppressed lists matching pairs skipped by docuguard:ignore directives.
+	Suppressed []SuppressedPair `json:"suppressed,omitempty"`
+	// Usage is the LLM token usage and estimated cost of the run.
+	Usage *Usage `json:"usage,omitempty"`
 	// ExecutionTimeMs is the execution time in milliseconds.
 	ExecutionTimeMs int64 `json:"execution_time_ms"`
 }
@@ -65,4 +84,28 @@ type PRCheckResult struct {
 	Reason string `json:"reason"`
 	// Suggestion provides a recommendation for fixing inconsistencies.
 	Suggestion string `json:"suggestion,omitempty"`
+	// Line is the documentation line a finding points at, when known.
+	Line int `json:"line,omitempty"`
+	// Baselined marks a known finding recorded in the baseline file; it is
+	// not counted as inconsistent.
+	Baselined bool `json:"baselined,omitempty"`
+	// Severity classifies the verdict using the configured confidence threshold.
+	Severity Severity `json:"severity,omitempty"`
+	// Status indicates whether the pair was checked, skipped, or the check failed.
+	Status CheckStatus `json:"status,omitempty"`
+	// Error holds the failure message when Status is StatusError.
+	Error string `json:"error,omitempty"`
 }
+
+// CheckStatus describes the outcome of checking a single pair.
+type CheckStatus string
+
+const (
+	// StatusChecked indicates the pair was analyzed and has a verdict.
+	StatusChecked CheckStatus = "checked"
+	// StatusError indicates the check failed, e.g. after exhausting LLM retries.
+	StatusError CheckStatus = "error"
+	// StatusSkipped indicates the pair was not checked because the LLM
+	// budget of the run was used up.
+	StatusSkipped CheckStatus = "skipped"
+)
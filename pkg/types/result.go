@@ -7,8 +7,11 @@ type CheckResult struct {
 	Confidence float64  `json:"confidence"`
 	Reason     string   `json:"reason"`
 	DocLoc     Location `json:"doc_location"`
+	Heading    string   `json:"heading,omitempty"` // 绑定块所在章节的标题
 	CodeLoc    Location `json:"code_location"`
 	Suggestion string   `json:"suggestion,omitempty"`
+	Severity   Severity `json:"severity,omitempty"`
+	Baselined  bool     `json:"baselined,omitempty"` // 已记录在基线文件中，不计入不一致
 }
 
 // Severity 严重程度
@@ -22,10 +25,17 @@ const (
 
 // Report 完整报告
 type Report struct {
-	TotalBindings   int           `json:"total_bindings"`
-	Consistent      int           `json:"consistent"`
-	Inconsistent    int           `json:"inconsistent"`
-	Errors          int           `json:"errors"`
-	Results         []CheckResult `json:"results"`
-	ExecutionTimeMs int64         `json:"execution_time_ms"`
+	TotalBindings   int              `json:"total_bindings"`
+	Consistent      int              `json:"consistent"`
+	Inconsistent    int              `json:"inconsistent"`
+	Errors          int              `json:"errors"`
+	Skipped         int              `json:"skipped"`   // LLM 预算用尽后未检查的绑定数
+	Blocking        int              `json:"blocking"`  // 达到 severity_threshold 的不一致数
+	Baselined       int              `json:"baselined"` // 被基线抑制的不一致数
+	Results         []CheckResult    `json:"results"`
+	StaleBaseline   []BaselineEntry  `json:"stale_baseline,omitempty"` // 已无法复现的基线条目
+	Suppressed      []SuppressedPair `json:"suppressed,omitempty"`     // 被 docuguard:ignore 跳过的绑定
+	Failures        []string         `json:"failures,omitempty"`       // 未能完成的检查及其错误信息
+	Usage           *Usage           `json:"usage,omitempty"`          // LLM token 用量与估算费用
+	ExecutionTimeMs int64            `json:"execution_time_ms"`
 }
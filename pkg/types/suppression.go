@@ -0,0 +1,30 @@
+package types
+
+// Suppression is an inline docuguard:ignore directive marking a
+// doc/code difference as intentional.
+type Suppression struct {
+	// Symbol limits the suppression to one code symbol; empty matches any symbol.
+	Symbol string `json:"symbol,omitempty"`
+	// Reason explains why the difference is intentional.
+	Reason string `json:"reason,omitempty"`
+	// File is the file containing the directive.
+	File string `json:"file"`
+	// Line is the line of the directive.
+	Line int `json:"line"`
+}
+
+// SuppressedPair is a doc/code pair that was not checked because of a Suppression.
+type SuppressedPair struct {
+	// DocFile is the documentation file.
+	DocFile string `json:"doc_file"`
+	// DocLine is the line of the section or binding.
+	DocLine int `json:"doc_line"`
+	// Heading is the section heading, if any.
+	Heading string `json:"heading,omitempty"`
+	// CodeFile is the file containing the symbol.
+	CodeFile string `json:"code_file"`
+	// Symbol is the code symbol.
+	Symbol string `json:"symbol"`
+	// Suppression is the directive that applies.
+	Suppression Suppression `json:"suppression"`
+}
@@ -0,0 +1,53 @@
+package types
+
+// TokenUsage counts the tokens of LLM requests.
+type TokenUsage struct {
+	// Calls is the number of requests answered by the provider. Cached
+	// and replayed responses are not counted.
+	Calls int `json:"calls"`
+	// PromptTokens is the number of input tokens billed.
+	PromptTokens int `json:"prompt_tokens"`
+	// CompletionTokens is the number of output tokens billed.
+	CompletionTokens int `json:"completion_tokens"`
+	// Cost is the estimated cost in USD; zero without a configured price.
+	Cost float64 `json:"cost,omitempty"`
+}
+
+// Tokens returns the prompt and completion tokens together.
+func (u TokenUsage) Tokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add accumulates o into u.
+func (u *TokenUsage) Add(o TokenUsage) {
+	u.Calls += o.Calls
+	u.PromptTokens += o.PromptTokens
+	u.CompletionTokens += o.CompletionTokens
+	u.Cost += o.Cost
+}
+
+// Usage is the LLM usage of a run, by stage.
+type Usage struct {
+	// Relevance covers the relevance filter of two-stage matching.
+	Relevance TokenUsage `json:"relevance"`
+	// Consistency covers the consistency checks.
+	Consistency TokenUsage `json:"consistency"`
+	// Rewrite covers documentation rewrites proposed for fixes.
+	Rewrite TokenUsage `json:"rewrite"`
+}
+
+// Total returns the usage of all stages together.
+func (u Usage) Total() TokenUsage {
+	var total TokenUsage
+	total.Add(u.Relevance)
+	total.Add(u.Consistency)
+	total.Add(u.Rewrite)
+	return total
+}
+
+// Add accumulates the usage of every stage of o into u.
+func (u *Usage) Add(o Usage) {
+	u.Relevance.Add(o.Relevance)
+	u.Consistency.Add(o.Consistency)
+	u.Rewrite.Add(o.Rewrite)
+}
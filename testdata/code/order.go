@@ -0,0 +1,31 @@
+package payment
+
+// Order 订单
+type Order struct {
+	Items []float64
+}
+
+// Invoice 发票
+type Invoice struct {
+	Amount float64
+	Tax    float64
+}
+
+// Total 计算订单总价
+func (o *Order) Total() float64 {
+	sum := 0.0
+	for _, item := range o.Items {
+		sum += item
+	}
+	return sum
+}
+
+// Total 计算含税金额
+func (i Invoice) Total() float64 {
+	return i.Amount + i.Tax
+}
+
+// Refund 退款，仅 Order 有此方法
+func (o *Order) Refund() float64 {
+	return o.Total()
+}
@@ -0,0 +1,25 @@
+package payment
+
+// Status 订单状态
+type Status int
+
+// 订单状态取值
+const (
+	// StatusPending 待支付
+	StatusPending Status = iota
+	// StatusPaid 已支付
+	StatusPaid
+	// StatusRefunded 已退款
+	StatusRefunded
+)
+
+// Gateway 支付网关
+type Gateway interface {
+	Charge(amount float64) error
+}
+
+// Rates 各地区税率
+type Rates map[string]float64
+
+// Amount 金额别名
+type Amount = float64